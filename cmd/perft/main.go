@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"tictactoe/internal/game"
+	"tictactoe/internal/perft"
+	"time"
+)
+
+func main() {
+	size := flag.Int("size", 3, "board size")
+	win := flag.Int("win", 0, "win length, defaults to the one of the board size")
+	depth := flag.Int("depth", -1, "maximum depth, negative to play every game to the end")
+	merge := flag.Bool("merge", false, "merge transpositions and count distinct positions")
+	gameStr := flag.String("game", "", "start position, an empty board by default")
+	flag.Parse()
+
+	g, err := startPosition(*gameStr, *size, *win)
+	if err != nil {
+		fmt.Println("invalid start position:", err)
+		os.Exit(1)
+	}
+
+	start := time.Now()
+	levels := perft.Perft(g, *depth, *merge)
+
+	fmt.Printf("%5s %15s %15s %15s %15s %15s\n", "depth", "nodes", "terminal", "x won", "o won", "draw")
+	for _, l := range levels {
+		fmt.Println(l)
+	}
+	fmt.Println(perft.Total(levels))
+	fmt.Println("finished in", time.Since(start))
+
+	if ref, ok := perft.References[g.GetMapKey()]; ok && *gameStr == "" && *depth < 0 {
+		expected := ref.Games
+		if *merge {
+			expected = ref.Positions
+		}
+
+		if perft.Total(levels) != perft.Total(expected) {
+			fmt.Println("does not match the reference", perft.Total(expected))
+			os.Exit(1)
+		}
+
+		fmt.Println("matches the reference")
+	}
+}
+
+func startPosition(str string, size, win int) (*game.Game, error) {
+	if str != "" {
+		g, err := game.FromString(str)
+		if err != nil {
+			return nil, err
+		}
+
+		if win > 0 {
+			g.WinLength = win
+		}
+
+		if err := g.ValidateWinLength(); err != nil {
+			return nil, err
+		}
+
+		return g, nil
+	}
+
+	if win <= 0 {
+		win = game.DefaultWinLength(size)
+	}
+
+	return game.NewGame(size, win)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"tictactoe/internal/game"
+	"tictactoe/internal/pns"
+	"time"
+)
+
+func main() {
+	size := flag.Int("size", 3, "board size")
+	win := flag.Int("win", 0, "win length, defaults to the one of the board size")
+	gameStr := flag.String("game", "", "position to prove, an empty board by default")
+	duration := flag.Duration("time", 0, "time limit of the run, none by default")
+	nodes := flag.Uint64("nodes", 0, "node limit of the run, none by default")
+	entries := flag.Int("entries", pns.DefaultMaxEntries, "maximum entries of each table")
+	statePath := flag.String("state", "", "file to resume the proof from and to save it to when it stops early")
+	proofs := flag.String("proofs", "./proofs", "directory of the proofs")
+	flag.Parse()
+
+	c := pns.Config{MaxEntries: *entries, MaxNodes: *nodes, Duration: *duration}
+
+	s, err := solver(*size, *win, *gameStr, *statePath, c)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	start := time.Now()
+	v := s.Run()
+	fmt.Printf("%s after %d nodes in %s\n", v, s.Nodes(), time.Since(start))
+
+	if v == pns.ValueUnknown {
+		if *statePath == "" {
+			fmt.Println("the proof is not finished, use -state to resume it later")
+			return
+		}
+
+		if err := s.Save(*statePath); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		fmt.Println("saved the state to", *statePath)
+		return
+	}
+
+	p := s.Proof(v)
+
+	g, err := game.FromString(p.Game)
+	if err == nil {
+		g.WinLength = p.WinLength
+		err = pns.SaveProof(*proofs, g, p)
+	}
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if *statePath != "" {
+		os.Remove(*statePath)
+	}
+}
+
+func solver(size, win int, gameStr, statePath string, c pns.Config) (*pns.Solver, error) {
+	if statePath != "" {
+		if _, err := os.Stat(statePath); err == nil {
+			return pns.Resume(statePath, c)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if win <= 0 {
+		win = game.DefaultWinLength(size)
+	}
+
+	g, err := game.NewGame(size, win)
+	if gameStr != "" {
+		g, err = game.FromString(gameStr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if gameStr != "" && flagSet("win") {
+		g.WinLength = win
+
+		if err := g.ValidateWinLength(); err != nil {
+			return nil, err
+		}
+	}
+
+	return pns.New(g, c)
+}
+
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+
+	return set
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"tictactoe/internal/game"
+	"tictactoe/internal/startpos"
+	"time"
+)
+
+func main() {
+	size := flag.Int("size", 3, "board size")
+	win := flag.Int("win", 0, "win length, defaults to the one of the board size")
+	stones := flag.Int("stones", 1, "pre-placed stones per side")
+	threshold := flag.Int("threshold", 8, "maximum absolute evaluation")
+	outcome := flag.String("outcome", "", "required solved outcome: draw, x or o")
+	count := flag.Int("count", 10, "number of positions")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	diagram := flag.Bool("diagram", false, "print board diagrams")
+	flag.Parse()
+
+	if *win <= 0 {
+		*win = game.DefaultWinLength(*size)
+	}
+
+	c := startpos.Config{
+		Size:      *size,
+		WinLength: *win,
+		Stones:    *stones,
+		Threshold: *threshold,
+		Outcome:   startpos.Outcome(*outcome),
+		Count:     *count,
+	}
+
+	positions, err := startpos.Generate(c, rand.New(rand.NewSource(*seed)))
+
+	for _, pos := range positions {
+		fmt.Printf("%s\twin=%d\teval=%d\tvalue=%s\n", pos.Game, pos.WinLength, pos.Evaluation, pos.Value)
+
+		if *diagram {
+			g, _ := game.FromString(pos.Game)
+			g.WinLength = pos.WinLength
+			fmt.Println(g.Diagram(game.DiagramOptions{}))
+		}
+	}
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,90 @@
+package fog
+
+import (
+	"errors"
+	"math/rand"
+	"tictactoe/internal/game"
+	"tictactoe/internal/heuristic"
+)
+
+const DefaultSamples = 200
+
+// MaxSamples bounds the samples of a single move.
+const MaxSamples = 2000
+
+// BestMove plays under uncertainty from the view alone: it samples hidden
+// boards consistent with the view, scores every candidate cell with the
+// heuristic on each of them and returns the cell with the highest total. A
+// cell which is occupied in a sample scores nothing there, as the attempt
+// would be rejected.
+func BestMove(v View, samples int, rng *rand.Rand) (int, error) {
+	p := game.Player(v.Player[0])
+	board := []game.Player(v.Board)
+
+	var candidates []int
+	hidden := v.OpponentStones
+
+	for i, c := range board {
+		switch c {
+		case game.PlayerNone:
+			candidates = append(candidates, i)
+		case p.Opponent():
+			hidden--
+		}
+	}
+
+	if len(candidates) == 0 {
+		return 0, errors.New("no moves left")
+	}
+
+	if hidden < 0 || hidden > len(candidates) {
+		return 0, errors.New("view is inconsistent")
+	}
+
+	totals := make([]int, len(board))
+
+	for n := 0; n < samples; n++ {
+		g := sample(v, board, candidates, hidden, rng)
+		windows := heuristic.CellWindows(g)
+
+		for _, i := range candidates {
+			if g.Board[i] == game.PlayerNone {
+				totals[i] += heuristic.ScoreMove(g, windows, i)
+			}
+		}
+	}
+
+	best := candidates[0]
+	for _, i := range candidates {
+		if totals[i] > totals[best] {
+			best = i
+		}
+	}
+
+	return best, nil
+}
+
+// sample places the hidden opponent stones at random into unknown cells,
+// preferring boards where the opponent has not already completed a line.
+func sample(v View, board []game.Player, candidates []int, hidden int, rng *rand.Rand) *game.Game {
+	var g *game.Game
+
+	for attempt := 0; attempt < 10; attempt++ {
+		g, _ = game.NewGame(v.Size, v.WinLength)
+		copy(g.Board, board)
+		g.PlayerTurn = game.Player(v.Player[0])
+
+		for _, k := range rng.Perm(len(candidates))[:hidden] {
+			g.Board[candidates[k]] = g.PlayerTurn.Opponent()
+		}
+
+		g.CheckWin()
+		if g.PlayerWon == game.PlayerNone {
+			break
+		}
+	}
+
+	g.PlayerWon = game.PlayerNone
+
+	return g
+}
@@ -0,0 +1,263 @@
+package fog
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"tictactoe/internal/game"
+	"time"
+)
+
+// SessionTTL is how long a session is kept after its last use.
+const SessionTTL = 24 * time.Hour
+
+// MaxSessions is how many sessions a store keeps, the least recently used one
+// makes room for a new session.
+const MaxSessions = 10000
+
+// Config of a fog-of-war game. With CostsTurn an attempt onto an occupied
+// cell passes the turn to the opponent, otherwise the player tries again.
+type Config struct {
+	Size      int
+	WinLength int
+	CostsTurn bool
+}
+
+// Session is a fog-of-war (Kriegspiel) game: players only see their own
+// stones and the enemy stones revealed by their rejected attempts. Each
+// player acts with a secret token, so knowing the ID is not enough to see
+// the other side.
+type Session struct {
+	ID     string
+	Config Config
+	Game   *game.Game
+
+	tokens   map[game.Player]string
+	revealed map[game.Player][]int
+	used     time.Time
+	mu       sync.Mutex
+}
+
+// View is what a player knows about the game.
+type View struct {
+	Player         string `json:"player"`
+	Board          string `json:"board"`
+	Turn           string `json:"turn"`
+	Won            string `json:"won"`
+	Over           bool   `json:"over"`
+	OpponentStones int    `json:"opponentStones"`
+	Size           int    `json:"size"`
+	WinLength      int    `json:"winLength"`
+}
+
+type Attempt struct {
+	Placed   bool `json:"placed"`
+	Revealed bool `json:"revealed"`
+}
+
+func NewSession(config Config) (*Session, error) {
+	g, err := game.NewGame(config.Size, config.WinLength)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session{
+		ID:       id,
+		Config:   config,
+		Game:     g,
+		tokens:   map[game.Player]string{},
+		revealed: map[game.Player][]int{},
+		used:     time.Now(),
+	}
+
+	for _, p := range []game.Player{game.PlayerX, game.PlayerO} {
+		if s.tokens[p], err = randomHex(16); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Token returns the secret token of p, to be handed to that player only.
+func (s *Session) Token(p game.Player) string {
+	return s.tokens[p]
+}
+
+// Player returns the player the token belongs to.
+func (s *Session) Player(token string) (game.Player, error) {
+	for p, t := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return p, nil
+		}
+	}
+
+	return 0, errors.New("invalid fog session token")
+}
+
+// Try attempts to place a stone of p into the cell. An occupied cell is
+// revealed to p and, depending on the config, costs the turn.
+func (s *Session) Try(p game.Player, cell int) (Attempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := s.Game
+
+	if g.IsOver() {
+		return Attempt{}, errors.New("game is already over")
+	}
+
+	if g.PlayerTurn != p {
+		return Attempt{}, fmt.Errorf("it is not %c's turn", p)
+	}
+
+	if cell < 0 || cell >= len(g.Board) {
+		return Attempt{}, fmt.Errorf("cell %d is out of the board", cell)
+	}
+
+	switch g.Board[cell] {
+	case game.PlayerNone:
+		g.MakeMoveByIndex(cell)
+		return Attempt{Placed: true}, nil
+	case p:
+		return Attempt{}, fmt.Errorf("cell %s is already yours", g.FormatMove(cell))
+	}
+
+	if !slices.Contains(s.revealed[p], cell) {
+		s.revealed[p] = append(s.revealed[p], cell)
+	}
+
+	if s.Config.CostsTurn {
+		g.PlayerTurn = p.Opponent()
+	}
+
+	return Attempt{Revealed: true}, nil
+}
+
+// View returns the board as seen by p, unknown cells are empty.
+func (s *Session) View(p game.Player) View {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := s.Game
+	board := make([]game.Player, len(g.Board))
+	opponent := 0
+
+	for i, c := range g.Board {
+		board[i] = game.PlayerNone
+
+		switch {
+		case c == p:
+			board[i] = p
+		case c == p.Opponent():
+			opponent++
+			if slices.Contains(s.revealed[p], i) {
+				board[i] = c
+			}
+		}
+	}
+
+	v := View{
+		Player:         string(p),
+		Board:          string(board),
+		Turn:           string(g.PlayerTurn),
+		Won:            string(g.PlayerWon),
+		Over:           g.IsOver(),
+		OpponentStones: opponent,
+		Size:           g.Size,
+		WinLength:      g.WinLength,
+	}
+
+	// the whole board is shown once the game is over
+	if v.Over {
+		v.Board = string(g.Board)
+	}
+
+	return v
+}
+
+// Store keeps the sessions in memory, the hidden boards never leave the
+// server. Sessions unused for SessionTTL are dropped, and beyond MaxSessions
+// the least recently used one is.
+type Store struct {
+	sessions map[string]*Session
+	mu       sync.Mutex
+}
+
+func NewStore() *Store {
+	return &Store{sessions: map[string]*Session{}}
+}
+
+func (st *Store) Add(s *Session) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.expire(time.Now())
+
+	if len(st.sessions) >= MaxSessions {
+		st.evict()
+	}
+
+	st.sessions[s.ID] = s
+}
+
+func (st *Store) Get(id string) (*Session, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := time.Now()
+
+	s, ok := st.sessions[id]
+	if ok && now.Sub(s.used) > SessionTTL {
+		delete(st.sessions, id)
+		ok = false
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown fog session %q", id)
+	}
+
+	s.used = now
+
+	return s, nil
+}
+
+func (st *Store) expire(now time.Time) {
+	for id, s := range st.sessions {
+		if now.Sub(s.used) > SessionTTL {
+			delete(st.sessions, id)
+		}
+	}
+}
+
+func (st *Store) evict() {
+	var oldest *Session
+
+	for _, s := range st.sessions {
+		if oldest == nil || s.used.Before(oldest.used) {
+			oldest = s
+		}
+	}
+
+	if oldest != nil {
+		delete(st.sessions, oldest.ID)
+	}
+}
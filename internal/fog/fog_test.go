@@ -0,0 +1,130 @@
+package fog
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"tictactoe/internal/game"
+	"time"
+)
+
+func TestTry(t *testing.T) {
+	s, _ := NewSession(Config{Size: 3, WinLength: 3, CostsTurn: true})
+
+	if _, err := s.Try(game.PlayerX, 4); err != nil {
+		t.Fatalf("Failed to place a stone: %v", err)
+	}
+
+	if v := s.View(game.PlayerO); v.Board != "_________" || v.OpponentStones != 1 {
+		t.Fatalf("Expected the stone to be hidden from O, got %s %d", v.Board, v.OpponentStones)
+	}
+
+	attempt, err := s.Try(game.PlayerO, 4)
+	if err != nil {
+		t.Fatalf("Failed to make an attempt: %v", err)
+	}
+
+	if attempt.Placed || !attempt.Revealed {
+		t.Fatalf("Expected the attempt to be rejected and revealed, got %+v", attempt)
+	}
+
+	if v := s.View(game.PlayerO); v.Board != "____X____" || v.Turn != "X" {
+		t.Fatalf("Expected the revealed stone and X's turn, got %s %s", v.Board, v.Turn)
+	}
+}
+
+func TestTryKeepsTurn(t *testing.T) {
+	s, _ := NewSession(Config{Size: 3, WinLength: 3})
+	s.Try(game.PlayerX, 4)
+	s.Try(game.PlayerO, 4)
+
+	if s.Game.PlayerTurn != game.PlayerO {
+		t.Fatalf("Expected O to try again, got %c", s.Game.PlayerTurn)
+	}
+}
+
+func TestBestMoveCompletesLine(t *testing.T) {
+	v := View{Player: "X", Board: "XX_______", OpponentStones: 2, Size: 3, WinLength: 3}
+
+	move, err := BestMove(v, 50, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to get the best move: %v", err)
+	}
+
+	if move != 2 {
+		t.Fatalf("Expected the winning move 2, got %d", move)
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	s, _ := NewSession(Config{Size: 3, WinLength: 3})
+
+	if p, err := s.Player(s.Token(game.PlayerO)); err != nil || p != game.PlayerO {
+		t.Fatalf("Expected the token of O, got %c %v", p, err)
+	}
+
+	for _, token := range []string{"", "X", s.ID} {
+		if _, err := s.Player(token); err == nil {
+			t.Fatalf("Expected an error for token %q", token)
+		}
+	}
+}
+
+func TestStoreExpires(t *testing.T) {
+	st := NewStore()
+	s, _ := NewSession(Config{Size: 3, WinLength: 3})
+	st.Add(s)
+
+	s.used = time.Now().Add(-SessionTTL - time.Minute)
+
+	if _, err := st.Get(s.ID); err == nil {
+		t.Fatalf("Expected an expired session to be dropped")
+	}
+}
+
+func TestStoreEvicts(t *testing.T) {
+	st := NewStore()
+
+	first, _ := NewSession(Config{Size: 3, WinLength: 3})
+	st.Add(first)
+
+	for i := 1; i < MaxSessions; i++ {
+		s, _ := NewSession(Config{Size: 3, WinLength: 3})
+		st.Add(s)
+	}
+
+	// the first session is used again, so the second one is the oldest
+	if _, err := st.Get(first.ID); err != nil {
+		t.Fatalf("Failed to get the first session: %v", err)
+	}
+
+	extra, _ := NewSession(Config{Size: 3, WinLength: 3})
+	st.Add(extra)
+
+	if len(st.sessions) != MaxSessions {
+		t.Fatalf("Expected %d sessions, got %d", MaxSessions, len(st.sessions))
+	}
+
+	if _, err := st.Get(first.ID); err != nil {
+		t.Fatalf("Expected the recently used session to be kept")
+	}
+
+	if _, err := st.Get(extra.ID); err != nil {
+		t.Fatalf("Expected the new session to be added")
+	}
+}
+
+func TestBestMoveEmptyBoard(t *testing.T) {
+	for size, center := range map[int]int{3: 4, 7: 24} {
+		v := View{Player: "X", Board: strings.Repeat("_", size*size), Size: size, WinLength: game.DefaultWinLength(size)}
+
+		move, err := BestMove(v, DefaultSamples, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Fatalf("Failed to get the best move: %v", err)
+		}
+
+		if move != center {
+			t.Fatalf("Expected the center %d of an empty %dx%d board, got %d", center, size, size, move)
+		}
+	}
+}
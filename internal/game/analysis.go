@@ -0,0 +1,157 @@
+package game
+
+import (
+	"slices"
+)
+
+// ThreatLine is a win position which only the player has stones in.
+type ThreatLine struct {
+	Cells  []int `json:"cells"`
+	Empty  []int `json:"empty"`
+	Stones int   `json:"stones"`
+}
+
+type PlayerAnalysis struct {
+	WinningCells  []int        `json:"winningCells"`
+	OpenLines     []ThreatLine `json:"openLines"`
+	HalfOpenLines []ThreatLine `json:"halfOpenLines"`
+	ForkCells     []int        `json:"forkCells"`
+}
+
+type Analysis struct {
+	X         PlayerAnalysis `json:"x"`
+	O         PlayerAnalysis `json:"o"`
+	DeadCells []int          `json:"deadCells"`
+}
+
+// Analyze reports the threats of both players: the cells which win at once,
+// lines one and two stones short of a win by how many of their ends are
+// free, the cells which create two threats at once, and the cells no line
+// can be won through anymore.
+func (g *Game) Analyze() Analysis {
+	return Analysis{
+		X:         g.analyzePlayer(PlayerX),
+		O:         g.analyzePlayer(PlayerO),
+		DeadCells: g.GetDeadCells(),
+	}
+}
+
+func (g *Game) analyzePlayer(p Player) PlayerAnalysis {
+	res := PlayerAnalysis{
+		WinningCells:  []int{},
+		OpenLines:     []ThreatLine{},
+		HalfOpenLines: []ThreatLine{},
+		ForkCells:     []int{},
+	}
+
+	created := map[int][]int{}
+
+	for _, positions := range g.GetWinPositions() {
+		line, ok := g.threatLine(positions, p)
+		if !ok || line.Stones < len(positions)-2 || line.Stones == len(positions) {
+			continue
+		}
+
+		if line.Stones == len(positions)-1 && !slices.Contains(res.WinningCells, line.Empty[0]) {
+			res.WinningCells = append(res.WinningCells, line.Empty[0])
+		}
+
+		if line.Stones == len(positions)-2 {
+			for k, i := range line.Empty {
+				other := line.Empty[1-k]
+				if !slices.Contains(created[i], other) {
+					created[i] = append(created[i], other)
+				}
+			}
+		}
+
+		switch g.freeEnds(positions) {
+		case 2:
+			res.OpenLines = append(res.OpenLines, line)
+		case 1:
+			res.HalfOpenLines = append(res.HalfOpenLines, line)
+		}
+	}
+
+	for i, threats := range created {
+		if len(threats) >= 2 {
+			res.ForkCells = append(res.ForkCells, i)
+		}
+	}
+
+	slices.Sort(res.WinningCells)
+	slices.Sort(res.ForkCells)
+
+	return res
+}
+
+// GetDeadCells returns the empty cells which are not a part of any win
+// position still winnable by either player.
+func (g *Game) GetDeadCells() []int {
+	alive := make([]bool, len(g.Board))
+
+	for _, positions := range g.GetWinPositions() {
+		_, x := g.threatLine(positions, PlayerX)
+		_, o := g.threatLine(positions, PlayerO)
+
+		if x || o {
+			for _, i := range positions {
+				alive[i] = true
+			}
+		}
+	}
+
+	res := []int{}
+	for i, p := range g.Board {
+		if p == PlayerNone && !alive[i] {
+			res = append(res, i)
+		}
+	}
+
+	return res
+}
+
+func (g *Game) threatLine(positions []int, p Player) (ThreatLine, bool) {
+	line := ThreatLine{Cells: positions}
+
+	for _, i := range positions {
+		switch g.Board[i] {
+		case p:
+			line.Stones++
+		case PlayerNone:
+			line.Empty = append(line.Empty, i)
+		default:
+			return ThreatLine{}, false
+		}
+	}
+
+	return line, true
+}
+
+// freeEnds counts the empty cells extending a straight win position on both
+// sides. Positions which are not straight lines have no free ends.
+func (g *Game) freeEnds(positions []int) int {
+	if len(positions) < 2 {
+		return 0
+	}
+
+	x0, y0 := positions[0]%g.Size, positions[0]/g.Size
+	dx, dy := positions[1]%g.Size-x0, positions[1]/g.Size-y0
+
+	for k, i := range positions {
+		if i%g.Size != x0+k*dx || i/g.Size != y0+k*dy {
+			return 0
+		}
+	}
+
+	n := len(positions)
+	ends := 0
+
+	for _, end := range []Point{{x0 - dx, y0 - dy}, {x0 + n*dx, y0 + n*dy}} {
+		if end.X >= 0 && end.Y >= 0 && end.X < g.Size && end.Y < g.Size && g.Board[end.X+end.Y*g.Size] == PlayerNone {
+			ends++
+		}
+	}
+
+	return ends
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAnalyzeWinningCells(t *testing.T) {
+	game, _ := FromString("_ XX_OO____")
+	a := game.Analyze()
+
+	if !slices.Equal(a.X.WinningCells, []int{2}) {
+		t.Fatalf("Expected X to win at 2, got %v", a.X.WinningCells)
+	}
+
+	if !slices.Equal(a.O.WinningCells, []int{5}) {
+		t.Fatalf("Expected O to win at 5, got %v", a.O.WinningCells)
+	}
+}
+
+func TestAnalyzeForks(t *testing.T) {
+	// X _ _
+	// _ O _
+	// _ _ X
+	game, _ := FromString("_ X___O___X")
+	a := game.Analyze()
+
+	if !slices.Equal(a.X.ForkCells, []int{2, 6}) {
+		t.Fatalf("Expected X forks at 2 and 6, got %v", a.X.ForkCells)
+	}
+
+	if len(a.O.ForkCells) != 0 {
+		t.Fatalf("Expected no O forks, got %v", a.O.ForkCells)
+	}
+}
+
+func TestAnalyzeOpenLines(t *testing.T) {
+	game, _ := NewGame(7, 4)
+	// _ X X X _ _ _ in the middle row
+	for _, i := range []int{22, 0, 23, 6, 24} {
+		game.MakeMoveByIndex(i)
+	}
+
+	a := game.Analyze()
+
+	if !slices.Equal(a.X.WinningCells, []int{21, 25}) {
+		t.Fatalf("Expected X to win at 21 and 25, got %v", a.X.WinningCells)
+	}
+
+	if len(a.X.OpenLines) == 0 || len(a.X.HalfOpenLines) == 0 {
+		t.Fatalf("Expected open and half-open lines, got %v and %v", a.X.OpenLines, a.X.HalfOpenLines)
+	}
+}
+
+func TestGetDeadCells(t *testing.T) {
+	// X O O
+	// O X X
+	// X _ O
+	game, _ := FromString("_ XOOOXXX_O")
+
+	if dead := game.GetDeadCells(); !slices.Equal(dead, []int{7}) {
+		t.Fatalf("Expected cell 7 to be dead, got %v", dead)
+	}
+}
@@ -0,0 +1,243 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type DiagramStyle int
+
+const (
+	DiagramASCII DiagramStyle = iota
+	DiagramUnicode
+)
+
+type DiagramOptions struct {
+	Style         DiagramStyle
+	Color         bool
+	HighlightLast bool
+}
+
+type diagramBorders struct {
+	top, middle, bottom [3]string
+	horizontal          string
+	vertical            string
+}
+
+var asciiBorders = diagramBorders{
+	top:        [3]string{"+", "+", "+"},
+	middle:     [3]string{"+", "+", "+"},
+	bottom:     [3]string{"+", "+", "+"},
+	horizontal: "-",
+	vertical:   "|",
+}
+
+var unicodeBorders = diagramBorders{
+	top:        [3]string{"┌", "┬", "┐"},
+	middle:     [3]string{"├", "┼", "┤"},
+	bottom:     [3]string{"└", "┴", "┘"},
+	horizontal: "─",
+	vertical:   "│",
+}
+
+const (
+	ansiReset   = "\033[0m"
+	ansiRed     = "\033[31m"
+	ansiBlue    = "\033[34m"
+	ansiReverse = "\033[7m"
+)
+
+// Diagram renders the board on several lines with algebraic coordinate
+// labels around it:
+//
+//	    a   b   c
+//	  +---+---+---+
+//	3 | X |   | O | 3
+//	  +---+---+---+
+//	2 |   |(X)|   | 2
+//	  +---+---+---+
+//	1 |   |   |   | 1
+//	  +---+---+---+
+//	    a   b   c
+func (g *Game) Diagram(opts DiagramOptions) string {
+	borders := asciiBorders
+	if opts.Style == DiagramUnicode {
+		borders = unicodeBorders
+	}
+
+	last := -1
+	if opts.HighlightLast && len(g.History) > 0 {
+		last = g.History[len(g.History)-1]
+	}
+
+	labelWidth := len(strconv.Itoa(g.Size))
+	indent := strings.Repeat(" ", labelWidth+1)
+
+	var sb strings.Builder
+
+	columns := make([]string, g.Size)
+	for x := range columns {
+		columns[x] = fmt.Sprintf("%-3s", " "+FormatColumn(x))
+	}
+	labels := strings.TrimRight(indent+" "+strings.Join(columns, " "), " ") + "\n"
+
+	border := func(b [3]string) string {
+		cells := make([]string, g.Size)
+		for x := range cells {
+			cells[x] = strings.Repeat(borders.horizontal, 3)
+		}
+		return indent + b[0] + strings.Join(cells, b[1]) + b[2] + "\n"
+	}
+
+	sb.WriteString(labels)
+	sb.WriteString(border(borders.top))
+
+	for y := 0; y < g.Size; y++ {
+		if y > 0 {
+			sb.WriteString(border(borders.middle))
+		}
+
+		row := strconv.Itoa(g.Size - y)
+		sb.WriteString(fmt.Sprintf("%*s ", labelWidth, row))
+
+		for x := 0; x < g.Size; x++ {
+			i := x + y*g.Size
+			sb.WriteString(borders.vertical)
+			sb.WriteString(g.diagramCell(i, i == last, opts.Color))
+		}
+
+		sb.WriteString(borders.vertical + " " + row + "\n")
+	}
+
+	sb.WriteString(border(borders.bottom))
+	sb.WriteString(labels)
+
+	return sb.String()
+}
+
+func (g *Game) diagramCell(i int, last, color bool) string {
+	symbol := " "
+	if p := g.Board[i]; p != PlayerNone {
+		symbol = string(p)
+	}
+
+	cell := " " + symbol + " "
+	if last && !color {
+		cell = "(" + symbol + ")"
+	}
+
+	if !color {
+		return cell
+	}
+
+	switch g.Board[i] {
+	case PlayerX:
+		cell = ansiRed + cell + ansiReset
+	case PlayerO:
+		cell = ansiBlue + cell + ansiReset
+	}
+
+	if last {
+		cell = ansiReverse + cell + ansiReset
+	}
+
+	return cell
+}
+
+var (
+	ansiPattern      = regexp.MustCompile("\033\\[[0-9;]*m")
+	separatorPattern = regexp.MustCompile("[|│┃]")
+	rowLabelPattern  = regexp.MustCompile(`^\s*\d+\s+|\s+\d+\s*$`)
+)
+
+// ParseDiagram reads a board from a pasted diagram. It accepts the output of
+// Diagram in both styles, with or without colors, as well as compact boards
+// with one symbol per cell separated by spaces ("X . O"). Empty cells may be
+// blank, ".", "_" or "-".
+func ParseDiagram(str string) (*Game, error) {
+	var rows [][]Player
+
+	for _, line := range strings.Split(ansiPattern.ReplaceAllString(str, ""), "\n") {
+		var cells []string
+
+		if separatorPattern.MatchString(line) {
+			parts := separatorPattern.Split(line, -1)
+			if len(parts) < 3 {
+				continue
+			}
+			cells = parts[1 : len(parts)-1]
+		} else {
+			line = rowLabelPattern.ReplaceAllString(line, "")
+			if !strings.ContainsAny(line, "XOxo._-") || isColumnLabels(line) || isBorder(line) {
+				continue
+			}
+			cells = strings.Fields(line)
+		}
+
+		row := make([]Player, len(cells))
+		for i, cell := range cells {
+			p, err := parseDiagramCell(cell)
+			if err != nil {
+				return nil, err
+			}
+			row[i] = p
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("no board found in the diagram")
+	}
+
+	var board []Player
+	for _, row := range rows {
+		if len(row) != len(rows) {
+			return nil, fmt.Errorf("diagram has %d rows but a row of %d cells", len(rows), len(row))
+		}
+		board = append(board, row...)
+	}
+
+	g := &Game{PlayerWon: PlayerNone}
+	if err := g.setBoard(board); err != nil {
+		return nil, err
+	}
+
+	g.CheckWin()
+
+	return g, nil
+}
+
+func parseDiagramCell(cell string) (Player, error) {
+	symbol := strings.Trim(strings.TrimSpace(cell), "()[]")
+
+	switch strings.ToUpper(symbol) {
+	case "", ".", "_", "-":
+		return PlayerNone, nil
+	case "X":
+		return PlayerX, nil
+	case "O":
+		return PlayerO, nil
+	case "#":
+		return PlayerBlocked, nil
+	default:
+		return PlayerNone, fmt.Errorf("invalid cell %q", cell)
+	}
+}
+
+func isColumnLabels(line string) bool {
+	for x, token := range strings.Fields(line) {
+		if token != FormatColumn(x) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isBorder(line string) bool {
+	return strings.Trim(line, " +-─┌┬┐├┼┤└┴┘") == ""
+}
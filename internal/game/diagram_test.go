@@ -0,0 +1,70 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiagram(t *testing.T) {
+	game, _ := FromString("_ X_O______")
+	game.MakeMoveByIndex(4)
+
+	expected := strings.Join([]string{
+		"    a   b   c",
+		"  +---+---+---+",
+		"3 | X |   | O | 3",
+		"  +---+---+---+",
+		"2 |   |(X)|   | 2",
+		"  +---+---+---+",
+		"1 |   |   |   | 1",
+		"  +---+---+---+",
+		"    a   b   c",
+		"",
+	}, "\n")
+
+	if diagram := game.Diagram(DiagramOptions{HighlightLast: true}); diagram != expected {
+		t.Fatalf("Expected diagram\n%s\ngot\n%s", expected, diagram)
+	}
+}
+
+func TestParseDiagram(t *testing.T) {
+	game, _ := NewGame(15, 5)
+	for _, i := range []int{0, 14, 112, 224} {
+		game.MakeMoveByIndex(i)
+	}
+
+	for _, opts := range []DiagramOptions{
+		{Style: DiagramASCII},
+		{Style: DiagramUnicode, HighlightLast: true},
+		{Style: DiagramUnicode, Color: true, HighlightLast: true},
+	} {
+		parsed, err := ParseDiagram(game.Diagram(opts))
+		if err != nil {
+			t.Fatalf("Failed to parse diagram: %v", err)
+		}
+
+		if parsed.String() != game.String() || parsed.PlayerTurn != game.PlayerTurn {
+			t.Fatalf("Expected %s, got %s", game, parsed)
+		}
+	}
+}
+
+func TestParseCompactDiagram(t *testing.T) {
+	game, err := ParseDiagram(`
+		3  X . O
+		2  . X .
+		1  O . X
+		   a b c
+	`)
+	if err != nil {
+		t.Fatalf("Failed to parse diagram: %v", err)
+	}
+
+	if game.String() != "X X_O_X_O_X" {
+		t.Fatalf("Unexpected game %s", game)
+	}
+
+	if _, err := ParseDiagram("X . O\n. X ."); err == nil {
+		t.Fatalf("Expected an error for a board which is not a square")
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxExpandSize is the size an expanding board stops growing at.
+const MaxExpandSize = MaxSize
+
+// Grow enlarges an expanding board when a stone is closer than ExpandMargin
+// to an edge. The board stays square and grows towards the crowded edges;
+// OriginX and OriginY keep the stable coordinates of the cells, so a cell is
+// addressed the same way before and after the growth. It reports whether the
+// board has grown.
+func (g *Game) Grow() bool {
+	if g.ExpandMargin <= 0 || g.Size >= MaxExpandSize {
+		return false
+	}
+
+	minX, minY, maxX, maxY := g.Size, g.Size, -1, -1
+	for i, p := range g.Board {
+		if p != PlayerX && p != PlayerO {
+			continue
+		}
+
+		x, y := i%g.Size, i/g.Size
+		minX, minY = min(minX, x), min(minY, y)
+		maxX, maxY = max(maxX, x), max(maxY, y)
+	}
+
+	if maxX < 0 {
+		return false
+	}
+
+	m := g.ExpandMargin
+	left, top := max(m-minX, 0), max(m-minY, 0)
+	right, bottom := max(m-(g.Size-1-maxX), 0), max(m-(g.Size-1-maxY), 0)
+
+	grow := min(max(left+right, top+bottom), MaxExpandSize-g.Size)
+	if grow <= 0 {
+		return false
+	}
+
+	offsetX := min(left+(grow-left-right)/2, grow)
+	offsetY := min(top+(grow-top-bottom)/2, grow)
+
+	g.ScaleBoardAt(g.Size+grow, g.WinLength, max(offsetX, 0), max(offsetY, 0))
+
+	return true
+}
+
+// FormatStable returns the stable coordinates "x,y" of a cell of an
+// expanding board.
+func (g *Game) FormatStable(i int) string {
+	return fmt.Sprintf("%d,%d", i%g.Size+g.OriginX, i/g.Size+g.OriginY)
+}
+
+func (g *Game) ParseStable(str string) (int, error) {
+	xs, ys, ok := strings.Cut(strings.TrimSpace(str), ",")
+	if !ok {
+		return 0, fmt.Errorf("invalid coordinates %q", str)
+	}
+
+	x, err := strconv.Atoi(xs)
+	if err != nil {
+		return 0, fmt.Errorf("invalid coordinates %q", str)
+	}
+
+	y, err := strconv.Atoi(ys)
+	if err != nil {
+		return 0, fmt.Errorf("invalid coordinates %q", str)
+	}
+
+	x, y = x-g.OriginX, y-g.OriginY
+	if x < 0 || y < 0 || x >= g.Size || y >= g.Size {
+		return 0, fmt.Errorf("coordinates %q are out of the board", str)
+	}
+
+	return x + y*g.Size, nil
+}
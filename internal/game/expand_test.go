@@ -0,0 +1,58 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestGrow(t *testing.T) {
+	game, _ := NewGame(3, 3)
+	game.ExpandMargin = 1
+
+	if err := game.PlayMoves("1,1 0,1"); err != nil {
+		t.Fatalf("Failed to play moves: %v", err)
+	}
+
+	if game.Size != 4 || game.OriginX != -1 || game.OriginY != 0 {
+		t.Fatalf("Expected the board to grow to the left, got size %d origin %d,%d", game.Size, game.OriginX, game.OriginY)
+	}
+
+	if game.Board[2+1*4] != PlayerX || game.Board[1+1*4] != PlayerO {
+		t.Fatalf("Expected stones to keep their stable coordinates, got %s", game.Board)
+	}
+
+	if game.FormatMoves(game.History) != "1. 1,1 0,1" {
+		t.Fatalf("Unexpected move list %s", game.FormatMoves(game.History))
+	}
+
+	restored, err := FromString(game.String())
+	if err != nil {
+		t.Fatalf("Failed to create a new game from string: %v", err)
+	}
+
+	if restored.OriginX != -1 || restored.WinLength != 3 || restored.ExpandMargin != 1 {
+		t.Fatalf("Unexpected restored game %s", restored)
+	}
+}
+
+func TestGrowStopsAtMaxSize(t *testing.T) {
+	game, _ := NewGame(MaxExpandSize, 5)
+	game.ExpandMargin = 2
+	game.MakeMoveByIndex(0)
+
+	if game.Size != MaxExpandSize {
+		t.Fatalf("Expected the board not to grow beyond %d, got %d", MaxExpandSize, game.Size)
+	}
+}
+
+func TestWinTokenNeedsExpand(t *testing.T) {
+	for _, str := range []string{"_ _________ win=3", "_ _________ win=0 expand=1", "_ _________ win=-1 expand=1"} {
+		if _, err := FromString(str); err == nil {
+			t.Fatalf("Expected an error for %q", str)
+		}
+	}
+
+	g, err := FromString("_ _________ win=4 expand=2 ox=0 oy=0")
+	if err != nil || g.WinLength != 4 {
+		t.Fatalf("Expected an expanding board with win length 4, got %v", err)
+	}
+}
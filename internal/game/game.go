@@ -1,7 +1,12 @@
 package game
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"tictactoe/internal/util"
 )
 
@@ -12,9 +17,49 @@ type Game struct {
 	Board      []Player
 	Size       int
 	WinLength  int
+	WinShapes  []Shape
+	Capture    bool
+	CapturesX  int
+	CapturesO  int
+	Scoring    ScoringMode
+	ScoreX     int
+	ScoreO     int
+	History    []int
+	Rules      Ruleset
+
+	FirstPlayer   Player
+	StonesPerTurn int
+	Misere        bool
+	Directions    []Point
+
+	Opening        OpeningProtocol
+	OpeningChoices []OpeningChoice
+
+	Geometry Geometry
+
+	ExpandMargin int
+	OriginX      int
+	OriginY      int
+
+	// Ended is the termination which can not be seen on the board, such as
+	// a resignation or an adjudicated draw.
+	Ended      Termination
+	Adjudicate bool
 }
 
+// MaxSize is the largest board a game is played on, the largest of the
+// MapSizes.
+const MaxSize = 27
+
 func NewGame(s, l int) (*Game, error) {
+	if s < 1 || s > MaxSize {
+		return nil, fmt.Errorf("invalid board size %d, must be between 1 and %d", s, MaxSize)
+	}
+
+	if l < 1 || l > s {
+		return nil, fmt.Errorf("invalid win length %d for board size %d", l, s)
+	}
+
 	g := &Game{
 		PlayerTurn: PlayerX,
 		PlayerWon:  PlayerNone,
@@ -39,6 +84,26 @@ func (g *Game) Copy() *Game {
 	newGame.StepsCount = g.StepsCount
 	newGame.Size = g.Size
 	newGame.WinLength = g.WinLength
+	newGame.WinShapes = g.WinShapes
+	newGame.Capture = g.Capture
+	newGame.CapturesX = g.CapturesX
+	newGame.CapturesO = g.CapturesO
+	newGame.Scoring = g.Scoring
+	newGame.ScoreX = g.ScoreX
+	newGame.ScoreO = g.ScoreO
+	newGame.Rules = g.Rules
+	newGame.FirstPlayer = g.FirstPlayer
+	newGame.StonesPerTurn = g.StonesPerTurn
+	newGame.Misere = g.Misere
+	newGame.Directions = g.Directions
+	newGame.Opening = g.Opening
+	newGame.OpeningChoices = append([]OpeningChoice(nil), g.OpeningChoices...)
+	newGame.Geometry = g.Geometry
+	newGame.ExpandMargin = g.ExpandMargin
+	newGame.OriginX = g.OriginX
+	newGame.OriginY = g.OriginY
+	newGame.Ended = g.Ended
+	newGame.Adjudicate = g.Adjudicate
 
 	newGame.Board = make([]Player, len(g.Board))
 
@@ -46,18 +111,94 @@ func (g *Game) Copy() *Game {
 		newGame.Board[i] = player
 	}
 
+	newGame.History = make([]int, len(g.History))
+	copy(newGame.History, g.History)
+
 	return newGame
 }
 
 func (g *Game) GetMapKey() string {
-	return util.GetMapKey(g.Size, g.WinLength)
+	key := util.GetMapKey(g.Size, g.WinLength)
+
+	if len(g.WinShapes) > 0 {
+		key += "_" + ShapesKey(g.WinShapes)
+	}
+
+	if g.Scoring != ScoringNone {
+		key += "_" + g.Scoring.String()
+	}
+
+	if g.Geometry != GeometrySquare {
+		key += "_" + g.Geometry.String()
+	}
+
+	if g.ExpandMargin > 0 {
+		key += fmt.Sprintf("_expand%d", g.ExpandMargin)
+	}
+
+	if vk := g.variantKey(); vk != "" {
+		key += "_" + vk
+	}
+
+	return key
 }
 
+// SetWinShapes replaces straight lines of WinLength with the given shapes,
+// optionally extended with their rotations and reflections.
+func (g *Game) SetWinShapes(shapes []Shape, rotations, reflections bool) {
+	g.WinShapes = nil
+
+	for _, sh := range shapes {
+		for _, v := range sh.Variants(rotations, reflections) {
+			if !slices.ContainsFunc(g.WinShapes, func(w Shape) bool { return w.String() == v.String() }) {
+				g.WinShapes = append(g.WinShapes, v)
+			}
+		}
+	}
+
+	g.CheckWin()
+}
+
+func (g *Game) GetWinPositions() [][]int {
+	if g.Geometry == GeometryHex {
+		return g.filterWinPositions(GetHexWinPositions(g.Size, g.WinLength))
+	}
+
+	if len(g.WinShapes) > 0 {
+		return g.filterWinPositions(GetShapeWinPositions(g.Size, g.WinShapes))
+	}
+
+	return g.filterWinPositions(GetWinPositions(g.Size, g.WinLength))
+}
+
+// MakeMoveByIndex places a stone of the player to move and checks the lines.
+// Further effects of the rules, such as captures, are left to Ruleset.Apply.
 func (g *Game) MakeMoveByIndex(i int) {
+	g.placeStone(i)
+	g.settle()
+}
+
+func (g *Game) placeStone(i int) {
 	g.Board[i] = g.PlayerTurn
-	g.PlayerTurn = g.PlayerTurn.Opponent()
+
 	g.StepsCount++
+	if g.hasTurnOrder() {
+		g.PlayerTurn = g.turnAt(g.StepsCount)
+	} else {
+		g.PlayerTurn = g.PlayerTurn.Opponent()
+	}
+
+	g.History = append(g.History, i)
+}
+
+// settle decides the game after a stone was placed and grows an expanding
+// board.
+func (g *Game) settle() {
 	g.CheckWin()
+
+	if g.ExpandMargin > 0 {
+		g.Grow()
+	}
 }
 
 func (g *Game) MakeMoveByCoordinates(x, y int) {
@@ -65,13 +206,18 @@ func (g *Game) MakeMoveByCoordinates(x, y int) {
 }
 
 func (g *Game) CheckWin() {
-	var s, l = g.Size, g.WinLength
+	if g.Scoring != ScoringNone {
+		g.updateScores()
+		return
+	}
 
-	for _, positions := range GetWinPositions(s, l) {
+	rules := g.GetRules()
+
+	for _, positions := range g.GetWinPositions() {
 		var player = g.Board[positions[0]]
 		var count int
 
-		if player == PlayerNone {
+		if player != PlayerX && player != PlayerO {
 			continue
 		}
 
@@ -83,48 +229,141 @@ func (g *Game) CheckWin() {
 			count++
 		}
 
-		if count == g.WinLength {
-			g.PlayerWon = player
+		if count == len(positions) {
+			g.PlayerWon = rules.LineWinner(g, player)
 			break
 		}
 	}
+
+	if g.Adjudicate {
+		g.adjudicate()
+	}
+}
+
+// GetWinLine returns the cells of the completed win position which decided
+// the game or nil. In a misère game it is the line of the player who lost.
+func (g *Game) GetWinLine() []int {
+	if g.PlayerWon == PlayerNone {
+		return nil
+	}
+
+	rules := g.GetRules()
+
+	for _, positions := range g.GetWinPositions() {
+		owner := g.Board[positions[0]]
+		if owner != PlayerX && owner != PlayerO || rules.LineWinner(g, owner) != g.PlayerWon {
+			continue
+		}
+
+		if !slices.ContainsFunc(positions, func(i int) bool { return g.Board[i] != owner }) {
+			return positions
+		}
+	}
+
+	return nil
 }
 
 func (g *Game) ScaleBoard(s, l int) error {
-	newGame, err := NewGame(s, l)
+	offset := (s - g.Size) / 2
+
+	return g.ScaleBoardAt(s, l, offset, offset)
+}
+
+// ScaleBoardAt moves the position onto a board of size s where the old
+// board starts at the given offsets, the origin of stable coordinates is
+// moved along.
+func (g *Game) ScaleBoardAt(s, l, offsetX, offsetY int) error {
+	if offsetX < 0 || offsetY < 0 || offsetX+g.Size > s || offsetY+g.Size > s {
+		return fmt.Errorf("board of size %d does not fit into %d at %d,%d", g.Size, s, offsetX, offsetY)
+	}
+
+	// the line of an expanding board may be longer than the board so far
+	newGame, err := NewGame(s, min(l, s))
 	if err != nil {
 		return err
 	}
-
-	offset := (s - g.Size) / 2
+	newGame.WinLength = l
 
 	for x := 0; x < g.Size; x++ {
 		for y := 0; y < g.Size; y++ {
-			newX := x + offset
-			newY := y + offset
+			newX := x + offsetX
+			newY := y + offsetY
 			newGame.Board[newX+newY*s] = g.Board[x+y*g.Size]
 		}
 	}
 
 	newGame.PlayerTurn = g.PlayerTurn
+	newGame.PlayerWon = g.PlayerWon
 	newGame.StepsCount = g.StepsCount
+	newGame.WinShapes = g.WinShapes
+	newGame.Capture = g.Capture
+	newGame.CapturesX = g.CapturesX
+	newGame.CapturesO = g.CapturesO
+	newGame.Scoring = g.Scoring
+	newGame.Rules = g.Rules
+	newGame.FirstPlayer = g.FirstPlayer
+	newGame.StonesPerTurn = g.StonesPerTurn
+	newGame.Misere = g.Misere
+	newGame.Directions = g.Directions
+	newGame.Opening = g.Opening
+	newGame.OpeningChoices = g.OpeningChoices
+	newGame.ExpandMargin = g.ExpandMargin
+	newGame.OriginX = g.OriginX - offsetX
+	newGame.OriginY = g.OriginY - offsetY
+	newGame.Ended = g.Ended
+	newGame.Adjudicate = g.Adjudicate
 	newGame.CheckWin()
 
+	for _, i := range g.History {
+		newGame.History = append(newGame.History, i%g.Size+offsetX+(i/g.Size+offsetY)*s)
+	}
+
 	*g = *newGame
 
 	return nil
 }
 
 func (g *Game) IsFulfilled() bool {
-	return g.StepsCount == g.Size*g.Size
+	return !slices.Contains(g.Board, PlayerNone)
 }
 
 func (g *Game) IsOver() bool {
-	return g.PlayerWon != PlayerNone || g.IsFulfilled()
+	return g.PlayerWon != PlayerNone || g.IsFulfilled() || g.Ended != TerminationNone
 }
 
+// String returns the winner and the board, followed by "key=value" tokens
+// for the state which can not be inferred from the board itself.
 func (g *Game) String() string {
-	return fmt.Sprintf("%c %s", g.PlayerWon, g.Board)
+	str := fmt.Sprintf("%c %s", g.PlayerWon, g.Board)
+
+	for _, token := range g.extensions() {
+		str += " " + token
+	}
+
+	return str
+}
+
+// ValidateWinLength checks that a line of WinLength fits on the board, or
+// on the largest board an expanding board grows to.
+func (g *Game) ValidateWinLength() error {
+	limit := g.Size
+	if g.ExpandMargin > 0 {
+		limit = MaxExpandSize
+	}
+
+	if g.WinLength < 1 || g.WinLength > limit {
+		return fmt.Errorf("invalid win length %d for board size %d", g.WinLength, g.Size)
+	}
+
+	return nil
+}
+
+func DefaultWinLength(s int) int {
+	if s <= 4 {
+		return s
+	}
+
+	return s - 1
 }
 
 func FromString(str string) (*Game, error) {
@@ -135,8 +374,35 @@ func FromString(str string) (*Game, error) {
 		return nil, err
 	}
 
+	if err := g.setBoard(g.Board); err != nil {
+		return nil, err
+	}
+
+	if err := g.parseExtensions(strings.Fields(str)[2:]); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// setBoard infers the size, win length, side to move and steps count from
+// the stones on the board.
+func (g *Game) setBoard(board []Player) error {
+	size := int(math.Sqrt(float64(len(board))))
+	for size*size < len(board) {
+		size++
+	}
+
+	if size*size != len(board) {
+		return fmt.Errorf("board of %d cells is not a square", len(board))
+	}
+
+	if size > MaxSize {
+		return fmt.Errorf("board of size %d is larger than %d", size, MaxSize)
+	}
+
 	countX, countO := 0, 0
-	for _, p := range g.Board {
+	for _, p := range board {
 		switch p {
 		case PlayerX:
 			countX++
@@ -145,26 +411,171 @@ func FromString(str string) (*Game, error) {
 		}
 	}
 
-	if countX < countO {
-		g.PlayerTurn = PlayerX
-	} else {
-		g.PlayerTurn = PlayerO
+	g.PlayerTurn = sideToMove(countX, countO)
+	g.Board = board
+	g.StepsCount = countX + countO
+	g.Size = size
+	g.WinLength = DefaultWinLength(size)
+
+	return nil
+}
+
+// sideToMove returns the player to move of a board with the given stones:
+// X moves first, so X is to move whenever it has no more stones than O, the
+// empty board included. Game strings used to give O the move on boards with
+// as many X as O.
+func sideToMove(countX, countO int) Player {
+	if countX <= countO {
+		return PlayerX
 	}
 
-	g.StepsCount = countX + countO
+	return PlayerO
+}
 
-	for s := range MapSizes {
-		if s*s == len(g.Board) {
-			g.Size = s
-			break
+func (g *Game) extensions() []string {
+	var res []string
+
+	if g.Capture {
+		res = append(res,
+			fmt.Sprintf("turn=%c", g.PlayerTurn),
+			fmt.Sprintf("cx=%d", g.CapturesX),
+			fmt.Sprintf("co=%d", g.CapturesO),
+		)
+	}
+
+	if g.Scoring != ScoringNone {
+		res = append(res, "scoring="+g.Scoring.String())
+	}
+
+	res = append(res, g.BoardTokens()...)
+
+	if g.Adjudicate {
+		res = append(res, "adjudicate=1")
+	}
+
+	if g.Ended != TerminationNone {
+		res = append(res, "end="+g.Ended.String())
+	}
+
+	res = append(res, g.VariantTokens()...)
+
+	return append(res, g.openingTokens()...)
+}
+
+// BoardTokens returns the game string tokens of the shape of the board: its
+// geometry and the growth of an expanding board.
+func (g *Game) BoardTokens() []string {
+	var res []string
+
+	if g.Geometry != GeometrySquare {
+		res = append(res, "geometry="+g.Geometry.String())
+	}
+
+	if g.ExpandMargin > 0 {
+		res = append(res,
+			fmt.Sprintf("win=%d", g.WinLength),
+			fmt.Sprintf("expand=%d", g.ExpandMargin),
+			fmt.Sprintf("ox=%d", g.OriginX),
+			fmt.Sprintf("oy=%d", g.OriginY),
+		)
+	}
+
+	return res
+}
+
+func parseCaptures(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if n < 0 {
+		return 0, errors.New("negative capture count")
+	}
+
+	return n, nil
+}
+
+func (g *Game) parseExtensions(tokens []string) error {
+	turn, win := false, false
+
+	for _, token := range tokens {
+		key, value, ok := strings.Cut(token, "=")
+		if !ok {
+			return fmt.Errorf("invalid game token %q", token)
+		}
+
+		if ok, err := g.parseVariantToken(key, value); ok {
+			if err != nil {
+				return fmt.Errorf("invalid game token %q: %v", token, err)
+			}
+			continue
+		}
+
+		var err error
+
+		switch key {
+		case "turn":
+			turn = true
+			if value != string(PlayerX) && value != string(PlayerO) {
+				return fmt.Errorf("invalid turn %q", value)
+			}
+			g.PlayerTurn = Player(value[0])
+		case "cx":
+			g.Capture = true
+			g.CapturesX, err = parseCaptures(value)
+			g.StepsCount += 2 * g.CapturesX
+		case "co":
+			g.Capture = true
+			g.CapturesO, err = parseCaptures(value)
+			g.StepsCount += 2 * g.CapturesO
+		case "scoring":
+			g.Scoring, err = ParseScoringMode(value)
+		case "adjudicate":
+			g.Adjudicate = value == "1"
+		case "end":
+			g.Ended, err = ParseTermination(value)
+		case "win":
+			win = true
+			g.WinLength, err = strconv.Atoi(value)
+		case "expand":
+			g.ExpandMargin, err = strconv.Atoi(value)
+		case "ox":
+			g.OriginX, err = strconv.Atoi(value)
+		case "oy":
+			g.OriginY, err = strconv.Atoi(value)
+		case "geometry":
+			g.Geometry, err = ParseGeometry(value)
+		case "opening":
+			g.Opening, err = ParseOpeningProtocol(value)
+		case "choices":
+			g.OpeningChoices, err = ParseOpeningChoices(value)
+		default:
+			return fmt.Errorf("unknown game token %q", token)
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid game token %q: %v", token, err)
 		}
 	}
 
-	if g.Size <= 4 {
-		g.WinLength = g.Size
-	} else {
-		g.WinLength = g.Size - 1
+	// only expanding boards carry their win length, it can not be told from
+	// a board which is still growing
+	if win && g.ExpandMargin <= 0 {
+		return errors.New("win length is only given for expanding boards")
 	}
 
-	return g, nil
+	if err := g.ValidateWinLength(); err != nil {
+		return err
+	}
+
+	if !turn && g.hasTurnOrder() {
+		g.PlayerTurn = g.turnAt(g.StepsCount)
+	}
+
+	if g.Scoring != ScoringNone {
+		g.updateScores()
+	}
+
+	return nil
 }
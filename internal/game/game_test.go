@@ -190,3 +190,55 @@ func TestFromString(t *testing.T) {
 		t.Fatalf("Expected PlayerX to have won, got %v", game.PlayerWon)
 	}
 }
+
+func TestFromStringSideToMove(t *testing.T) {
+	for str, expected := range map[string]Player{
+		"_ _________": PlayerX,
+		"_ ____X____": PlayerO,
+		"_ X___O____": PlayerX,
+		"_ X___O_X__": PlayerO,
+		"_ O________": PlayerX,
+	} {
+		g, err := FromString(str)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", str, err)
+		}
+
+		if g.PlayerTurn != expected {
+			t.Fatalf("Expected %c to move in %q, got %c", expected, str, g.PlayerTurn)
+		}
+	}
+}
+
+func TestNewGameWinLength(t *testing.T) {
+	for _, l := range []int{-1, 0, 4} {
+		if _, err := NewGame(3, l); err == nil {
+			t.Fatalf("Expected an error for win length %d on a 3x3 board", l)
+		}
+	}
+
+	g, _ := FromString("_ _________")
+	g.WinLength = 0
+
+	if err := g.ValidateWinLength(); err == nil {
+		t.Fatalf("Expected an error for win length 0")
+	}
+
+	g.WinLength, g.ExpandMargin = 5, 2
+
+	if err := g.ValidateWinLength(); err != nil {
+		t.Fatalf("Expected an expanding board to grow to win length 5: %v", err)
+	}
+}
+
+func TestNewGameSize(t *testing.T) {
+	for _, s := range []int{-1, 0, MaxSize + 1, 100000} {
+		if _, err := NewGame(s, 3); err == nil {
+			t.Fatalf("Expected an error for board size %d", s)
+		}
+	}
+
+	if _, err := NewHexGame(100000, 3); err == nil {
+		t.Fatalf("Expected an error for a hexagon larger than the largest board")
+	}
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"tictactoe/internal/util"
+)
+
+type Geometry int
+
+const (
+	GeometrySquare Geometry = iota
+	// GeometryHex is a hexagon of hexagonal cells. It is stored in a square
+	// board of axial coordinates, q along x and r along y with the center
+	// in the middle, and the cells outside the hexagon are blocked.
+	GeometryHex
+)
+
+func (geo Geometry) String() string {
+	switch geo {
+	case GeometrySquare:
+		return "square"
+	case GeometryHex:
+		return "hex"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseGeometry(str string) (Geometry, error) {
+	for _, geo := range []Geometry{GeometrySquare, GeometryHex} {
+		if geo.String() == str {
+			return geo, nil
+		}
+	}
+
+	return GeometrySquare, fmt.Errorf("unknown geometry %q", str)
+}
+
+// HexAxes are the three directions hex lines run along in axial
+// coordinates.
+var HexAxes = []Point{{1, 0}, {0, 1}, {1, -1}}
+
+// NewHexGame returns a hexagon with the given number of rings around the
+// center cell.
+func NewHexGame(radius, l int) (*Game, error) {
+	if radius < 1 || l < 1 || l > 2*radius+1 {
+		return nil, fmt.Errorf("invalid hex radius %d with win length %d", radius, l)
+	}
+
+	g, err := NewGame(2*radius+1, l)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Geometry = GeometryHex
+
+	for i := range g.Board {
+		if !isInHexagon(i%g.Size, i/g.Size, g.Size) {
+			g.Board[i] = PlayerBlocked
+		}
+	}
+
+	return g, nil
+}
+
+func DefaultHexWinLength(radius int) int {
+	return min(max(3, radius+1), 2*radius+1)
+}
+
+func isInHexagon(x, y, s int) bool {
+	r := s / 2
+	q, rr := x-r, y-r
+
+	return x >= 0 && y >= 0 && x < s && y < s && q+rr >= -r && q+rr <= r
+}
+
+// GetHexWinPositions generates the lines of length l along the HexAxes
+// inside a hexagon stored in a board of size s.
+func GetHexWinPositions(s, l int) [][]int {
+	cacheKey := "hex_" + util.GetMapKey(s, l)
+
+	if cached := cachedWinPositions(cacheKey); cached != nil {
+		return cached
+	}
+
+	var res [][]int
+
+	for _, d := range HexAxes {
+		for y := 0; y < s; y++ {
+			for x := 0; x < s; x++ {
+				if !isInHexagon(x+(l-1)*d.X, y+(l-1)*d.Y, s) || !isInHexagon(x, y, s) {
+					continue
+				}
+
+				line := make([]int, l)
+				for i := range line {
+					line[i] = x + i*d.X + (y+i*d.Y)*s
+				}
+
+				res = append(res, line)
+			}
+		}
+	}
+
+	cacheWinPositions(cacheKey, res)
+
+	return res
+}
+
+// FormatAxial returns the axial coordinates "q,r" of a cell relative to the
+// center of a hex board.
+func FormatAxial(x, y, size int) string {
+	return fmt.Sprintf("%d,%d", x-size/2, y-size/2)
+}
+
+func ParseAxial(str string, size int) (int, int, error) {
+	qs, rs, ok := strings.Cut(strings.Trim(strings.TrimSpace(str), "()"), ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid axial coordinates %q", str)
+	}
+
+	q, err := strconv.Atoi(strings.TrimSpace(qs))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid axial coordinates %q", str)
+	}
+
+	r, err := strconv.Atoi(strings.TrimSpace(rs))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid axial coordinates %q", str)
+	}
+
+	x, y := q+size/2, r+size/2
+	if !isInHexagon(x, y, size) {
+		return 0, 0, fmt.Errorf("axial coordinates %q are out of the board", str)
+	}
+
+	return x, y, nil
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestNewHexGame(t *testing.T) {
+	game, err := NewHexGame(1, 3)
+	if err != nil {
+		t.Fatalf("Failed to create a hex game: %v", err)
+	}
+
+	if string(game.Board) != "#_______#" {
+		t.Fatalf("Unexpected hex board %s", string(game.Board))
+	}
+
+	if n := len(game.GetWinPositions()); n != 3 {
+		t.Fatalf("Expected 3 lines through the center, got %d", n)
+	}
+
+	if n := len(game.GetRules().LegalMoves(game)); n != 7 {
+		t.Fatalf("Expected 7 cells, got %d", n)
+	}
+}
+
+func TestHexWin(t *testing.T) {
+	game, _ := NewHexGame(2, 3)
+
+	moves, err := game.ParseMoves("1. -1,1 2,-2 2. 0,0 -2,0 3. 1,-1")
+	if err != nil {
+		t.Fatalf("Failed to parse axial moves: %v", err)
+	}
+
+	if err := game.ApplyMoves(moves); err != nil {
+		t.Fatalf("Failed to apply moves: %v", err)
+	}
+
+	if game.PlayerWon != PlayerX {
+		t.Fatalf("Expected PlayerX to win along the third axis, got %c", game.PlayerWon)
+	}
+
+	if game.FormatMoves(moves) != "1. -1,1 2,-2 2. 0,0 -2,0 3. 1,-1" {
+		t.Fatalf("Unexpected move list %s", game.FormatMoves(moves))
+	}
+
+	if _, err := game.ParseMove("2,1"); err == nil {
+		t.Fatalf("Expected an error for a cell outside the hexagon")
+	}
+
+	restored, err := FromString(game.String())
+	if err != nil || restored.Geometry != GeometryHex {
+		t.Fatalf("Failed to restore the hex game %s: %v", game, err)
+	}
+}
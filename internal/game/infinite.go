@@ -0,0 +1,179 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Point struct {
+	X int
+	Y int
+}
+
+var Directions = []Point{{1, 0}, {0, 1}, {1, 1}, {1, -1}}
+
+// InfiniteGame is k-in-a-row on an unbounded grid. Stones are kept in a sparse
+// map keyed by signed coordinates instead of a dense Board.
+type InfiniteGame struct {
+	PlayerTurn Player
+	PlayerWon  Player
+	StepsCount int
+	WinLength  int
+	Stones     map[Point]Player
+	Moves      []Point
+	WinLine    []Point
+}
+
+func NewInfiniteGame(l int) (*InfiniteGame, error) {
+	if l < 1 {
+		return nil, fmt.Errorf("invalid win length %d", l)
+	}
+
+	g := &InfiniteGame{
+		PlayerTurn: PlayerX,
+		PlayerWon:  PlayerNone,
+		WinLength:  l,
+		Stones:     map[Point]Player{},
+	}
+
+	return g, nil
+}
+
+func (g *InfiniteGame) Copy() *InfiniteGame {
+	newGame := *g
+
+	newGame.Stones = make(map[Point]Player, len(g.Stones))
+	for p, player := range g.Stones {
+		newGame.Stones[p] = player
+	}
+
+	newGame.Moves = make([]Point, len(g.Moves))
+	copy(newGame.Moves, g.Moves)
+
+	newGame.WinLine = make([]Point, len(g.WinLine))
+	copy(newGame.WinLine, g.WinLine)
+
+	return &newGame
+}
+
+func (g *InfiniteGame) Get(x, y int) Player {
+	if p, ok := g.Stones[Point{x, y}]; ok {
+		return p
+	}
+
+	return PlayerNone
+}
+
+func (g *InfiniteGame) MakeMove(x, y int) error {
+	if g.IsOver() {
+		return errors.New("game is already over")
+	}
+
+	p := Point{x, y}
+	if _, ok := g.Stones[p]; ok {
+		return fmt.Errorf("cell %d,%d is already taken", x, y)
+	}
+
+	g.Stones[p] = g.PlayerTurn
+	g.Moves = append(g.Moves, p)
+	g.PlayerTurn = g.PlayerTurn.Opponent()
+	g.StepsCount++
+	g.checkWin(p)
+
+	return nil
+}
+
+// Bounds returns the smallest rectangle containing every stone.
+func (g *InfiniteGame) Bounds() (Point, Point) {
+	if len(g.Moves) == 0 {
+		return Point{}, Point{}
+	}
+
+	minP, maxP := g.Moves[0], g.Moves[0]
+
+	for _, p := range g.Moves {
+		minP.X, minP.Y = min(minP.X, p.X), min(minP.Y, p.Y)
+		maxP.X, maxP.Y = max(maxP.X, p.X), max(maxP.Y, p.Y)
+	}
+
+	return minP, maxP
+}
+
+func (g *InfiniteGame) IsOver() bool {
+	return g.PlayerWon != PlayerNone
+}
+
+// String returns the winner, the win length and the moves in the order they
+// were made, e.g. "_ 5 0,0;1,0;-1,-1".
+func (g *InfiniteGame) String() string {
+	moves := make([]string, len(g.Moves))
+	for i, p := range g.Moves {
+		moves[i] = fmt.Sprintf("%d,%d", p.X, p.Y)
+	}
+
+	str := fmt.Sprintf("%c %d", g.PlayerWon, g.WinLength)
+	if len(moves) > 0 {
+		str += " " + strings.Join(moves, ";")
+	}
+
+	return str
+}
+
+func InfiniteFromString(str string) (*InfiniteGame, error) {
+	fields := strings.Fields(str)
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("invalid infinite game string %q", str)
+	}
+
+	l, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid win length: %v", err)
+	}
+
+	g, err := NewInfiniteGame(l)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 2 {
+		return g, nil
+	}
+
+	for _, move := range strings.Split(fields[2], ";") {
+		var x, y int
+		if _, err := fmt.Sscanf(move, "%d,%d", &x, &y); err != nil {
+			return nil, fmt.Errorf("invalid move %q: %v", move, err)
+		}
+
+		if err := g.MakeMove(x, y); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+func (g *InfiniteGame) checkWin(p Point) {
+	player := g.Stones[p]
+
+	for _, d := range Directions {
+		line := []Point{p}
+
+		for _, sign := range []int{1, -1} {
+			next := Point{p.X + d.X*sign, p.Y + d.Y*sign}
+
+			for g.Get(next.X, next.Y) == player {
+				line = append(line, next)
+				next = Point{next.X + d.X*sign, next.Y + d.Y*sign}
+			}
+		}
+
+		if len(line) >= g.WinLength {
+			g.PlayerWon = player
+			g.WinLine = line
+			return
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestInfiniteMakeMove(t *testing.T) {
+	g, _ := NewInfiniteGame(5)
+
+	if err := g.MakeMove(-100, 42); err != nil {
+		t.Fatalf("Failed to make a move: %v", err)
+	}
+
+	if g.Get(-100, 42) != PlayerX {
+		t.Fatalf("Expected PlayerX at -100,42, got %c", g.Get(-100, 42))
+	}
+
+	if err := g.MakeMove(-100, 42); err == nil {
+		t.Fatalf("Expected an error for an occupied cell")
+	}
+}
+
+func TestInfiniteCheckWin(t *testing.T) {
+	g, err := InfiniteFromString("_ 4 -1,-1;5,5;0,0;5,6;-2,-2;5,7;1,1")
+	if err != nil {
+		t.Fatalf("Failed to create an infinite game from string: %v", err)
+	}
+
+	if g.PlayerWon != PlayerX {
+		t.Fatalf("Expected PlayerX to win, got %c", g.PlayerWon)
+	}
+
+	if len(g.WinLine) != 4 {
+		t.Fatalf("Expected win line of 4 stones, got %v", g.WinLine)
+	}
+
+	minP, maxP := g.Bounds()
+	if minP != (Point{-2, -2}) || maxP != (Point{5, 7}) {
+		t.Fatalf("Unexpected bounds %v %v", minP, maxP)
+	}
+}
+
+func TestInfiniteString(t *testing.T) {
+	str := "_ 5 0,0;1,0;-1,-1"
+
+	g, err := InfiniteFromString(str)
+	if err != nil {
+		t.Fatalf("Failed to create an infinite game from string: %v", err)
+	}
+
+	if g.String() != str {
+		t.Fatalf("Expected game string to be %s, got %s", str, g.String())
+	}
+}
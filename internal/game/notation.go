@@ -0,0 +1,180 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatColumn names columns like spreadsheets do: a..z, aa..az, ba...
+func FormatColumn(x int) string {
+	var res []byte
+
+	for x++; x > 0; x = (x - 1) / 26 {
+		res = append([]byte{byte('a' + (x-1)%26)}, res...)
+	}
+
+	return string(res)
+}
+
+func ParseColumn(str string) (int, error) {
+	if str == "" {
+		return 0, errors.New("empty column")
+	}
+
+	x := 0
+	for _, c := range str {
+		if c < 'a' || c > 'z' {
+			return 0, fmt.Errorf("invalid column %q", str)
+		}
+		x = x*26 + int(c-'a') + 1
+	}
+
+	return x - 1, nil
+}
+
+// FormatCoordinates returns the algebraic name of a cell, where columns are
+// letters from the left and rows are numbers from the bottom: "a1", "h8".
+func FormatCoordinates(x, y, size int) string {
+	return FormatColumn(x) + strconv.Itoa(size-y)
+}
+
+func ParseCoordinates(str string, size int) (int, int, error) {
+	str = strings.ToLower(strings.TrimSpace(str))
+
+	split := strings.IndexFunc(str, func(c rune) bool { return c < 'a' || c > 'z' })
+	if split <= 0 {
+		return 0, 0, fmt.Errorf("invalid coordinates %q", str)
+	}
+
+	x, err := ParseColumn(str[:split])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	row, err := strconv.Atoi(str[split:])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid row in %q", str)
+	}
+
+	y := size - row
+	if x < 0 || x >= size || y < 0 || y >= size {
+		return 0, 0, fmt.Errorf("coordinates %q are out of the board", str)
+	}
+
+	return x, y, nil
+}
+
+// FormatMove returns the algebraic coordinates of the cell, the axial ones
+// on a hex board or the stable ones on an expanding board.
+func (g *Game) FormatMove(i int) string {
+	if g.ExpandMargin > 0 {
+		return g.FormatStable(i)
+	}
+
+	if g.Geometry == GeometryHex {
+		return FormatAxial(i%g.Size, i/g.Size, g.Size)
+	}
+
+	return FormatCoordinates(i%g.Size, i/g.Size, g.Size)
+}
+
+func (g *Game) ParseMove(str string) (int, error) {
+	if g.ExpandMargin > 0 {
+		return g.ParseStable(str)
+	}
+
+	parse := ParseCoordinates
+	if g.Geometry == GeometryHex {
+		parse = ParseAxial
+	}
+
+	x, y, err := parse(str, g.Size)
+	if err != nil {
+		return 0, err
+	}
+
+	return x + y*g.Size, nil
+}
+
+// FormatMoves returns a numbered move list: "1. c3 b2 2. a1".
+func (g *Game) FormatMoves(moves []int) string {
+	var tokens []string
+
+	for i, move := range moves {
+		if i%2 == 0 {
+			tokens = append(tokens, fmt.Sprintf("%d.", i/2+1))
+		}
+		tokens = append(tokens, g.GetRules().FormatMove(g, move))
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// ParseMoves reads a move list, move numbers are optional: "1. c3 b2 2. a1"
+// and "c3 b2 a1" are the same list.
+func (g *Game) ParseMoves(str string) ([]int, error) {
+	var res []int
+
+	for _, token := range moveTokens(str) {
+		move, err := g.GetRules().ParseMove(g, token)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, move)
+	}
+
+	return res, nil
+}
+
+// PlayMoves parses and makes the moves of the list one at a time, so every
+// move is read on the board left by the previous one. Moves of an expanding
+// board have to be played this way as the cell indices change when it grows.
+func (g *Game) PlayMoves(str string) error {
+	rules := g.GetRules()
+
+	for _, token := range moveTokens(str) {
+		move, err := rules.ParseMove(g, token)
+		if err != nil {
+			return err
+		}
+
+		if err := rules.Apply(g, move); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func moveTokens(str string) []string {
+	var res []string
+
+	for _, token := range strings.Fields(str) {
+		if strings.HasSuffix(token, ".") {
+			if _, err := strconv.Atoi(strings.TrimRight(token, ".")); err == nil {
+				continue
+			}
+		}
+
+		res = append(res, token)
+	}
+
+	return res
+}
+
+// ApplyMoves makes the moves one by one by the rules of the game and fails on
+// the first illegal one.
+func (g *Game) ApplyMoves(moves []int) error {
+	rules := g.GetRules()
+
+	for _, move := range moves {
+		if err := rules.Apply(g, move); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestFormatColumn(t *testing.T) {
+	cases := map[int]string{0: "a", 7: "h", 25: "z", 26: "aa", 27: "ab", 51: "az", 52: "ba", 701: "zz", 702: "aaa"}
+
+	for x, expected := range cases {
+		if FormatColumn(x) != expected {
+			t.Fatalf("Expected column %d to be %s, got %s", x, expected, FormatColumn(x))
+		}
+
+		parsed, err := ParseColumn(expected)
+		if err != nil || parsed != x {
+			t.Fatalf("Expected column %s to be %d, got %d (%v)", expected, x, parsed, err)
+		}
+	}
+}
+
+func TestParseCoordinates(t *testing.T) {
+	x, y, err := ParseCoordinates("a1", 3)
+	if err != nil || x != 0 || y != 2 {
+		t.Fatalf("Expected a1 to be 0,2, got %d,%d (%v)", x, y, err)
+	}
+
+	x, y, err = ParseCoordinates("aa19", 27)
+	if err != nil || x != 26 || y != 8 {
+		t.Fatalf("Expected aa19 to be 26,8, got %d,%d (%v)", x, y, err)
+	}
+
+	if _, _, err := ParseCoordinates("d1", 3); err == nil {
+		t.Fatalf("Expected an error for coordinates out of the board")
+	}
+}
+
+func TestMoveList(t *testing.T) {
+	game, _ := NewGame(3, 3)
+
+	moves, err := game.ParseMoves("1. c3 b2 2. a1")
+	if err != nil {
+		t.Fatalf("Failed to parse moves: %v", err)
+	}
+
+	if err := game.ApplyMoves(moves); err != nil {
+		t.Fatalf("Failed to apply moves: %v", err)
+	}
+
+	if game.String() != "_ __X_O_X__" {
+		t.Fatalf("Unexpected game %s", game)
+	}
+
+	if game.FormatMoves(game.History) != "1. c3 b2 2. a1" {
+		t.Fatalf("Unexpected move list %s", game.FormatMoves(game.History))
+	}
+
+	if err := game.ApplyMoves([]int{4}); err == nil {
+		t.Fatalf("Expected an error for an occupied cell")
+	}
+}
@@ -0,0 +1,241 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// OpeningProtocol balances the advantage of the first player. The players
+// are seated as SeatFirst, who opens the game with PlayerX, and SeatSecond;
+// swapping exchanges the colors of the seats.
+type OpeningProtocol int
+
+const (
+	OpeningNone OpeningProtocol = iota
+	// OpeningPie lets the second player swap after the first move.
+	OpeningPie
+	// OpeningSwap lets the first player place three stones (X, O, X), then
+	// the second player chooses a color.
+	OpeningSwap
+	// OpeningSwap2 is OpeningSwap where the second player may instead place
+	// two more stones (O, X) and leave the choice to the first player.
+	OpeningSwap2
+)
+
+func (op OpeningProtocol) String() string {
+	switch op {
+	case OpeningNone:
+		return "none"
+	case OpeningPie:
+		return "pie"
+	case OpeningSwap:
+		return "swap"
+	case OpeningSwap2:
+		return "swap2"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseOpeningProtocol(str string) (OpeningProtocol, error) {
+	for _, op := range []OpeningProtocol{OpeningNone, OpeningPie, OpeningSwap, OpeningSwap2} {
+		if op.String() == str {
+			return op, nil
+		}
+	}
+
+	return OpeningNone, fmt.Errorf("unknown opening protocol %q", str)
+}
+
+type OpeningChoice string
+
+const (
+	ChoiceStay  OpeningChoice = "stay"
+	ChoiceSwap  OpeningChoice = "swap"
+	ChoicePlace OpeningChoice = "place"
+)
+
+func ParseOpeningChoices(str string) ([]OpeningChoice, error) {
+	var res []OpeningChoice
+
+	for _, token := range strings.FieldsFunc(str, func(r rune) bool { return r == ',' || r == ' ' }) {
+		switch c := OpeningChoice(token); c {
+		case ChoiceStay, ChoiceSwap, ChoicePlace:
+			res = append(res, c)
+		default:
+			return nil, fmt.Errorf("unknown opening choice %q", token)
+		}
+	}
+
+	return res, nil
+}
+
+type Seat string
+
+const (
+	SeatFirst  Seat = "first"
+	SeatSecond Seat = "second"
+)
+
+func (s Seat) Other() Seat {
+	if s == SeatFirst {
+		return SeatSecond
+	}
+
+	return SeatFirst
+}
+
+type OpeningPhase string
+
+const (
+	PhasePlay   OpeningPhase = "play"
+	PhasePlace  OpeningPhase = "place"
+	PhaseChoose OpeningPhase = "choose"
+)
+
+// OpeningPhase returns what the protocol expects next and from whom.
+func (g *Game) OpeningPhase() (OpeningPhase, Seat) {
+	steps, choices := g.StepsCount, len(g.OpeningChoices)
+	placed := choices > 0 && g.OpeningChoices[0] == ChoicePlace
+
+	switch g.Opening {
+	case OpeningPie:
+		if steps == 1 && choices == 0 {
+			return PhaseChoose, SeatSecond
+		}
+	case OpeningSwap, OpeningSwap2:
+		switch {
+		case steps < 3:
+			return PhasePlace, SeatFirst
+		case steps == 3 && choices == 0:
+			return PhaseChoose, SeatSecond
+		case placed && steps < 5:
+			return PhasePlace, SeatSecond
+		case placed && steps == 5 && choices == 1:
+			return PhaseChoose, SeatFirst
+		}
+	}
+
+	return PhasePlay, g.SeatOf(g.PlayerTurn)
+}
+
+// OpeningOptions returns the choices available in PhaseChoose.
+func (g *Game) OpeningOptions() []OpeningChoice {
+	if phase, _ := g.OpeningPhase(); phase != PhaseChoose {
+		return nil
+	}
+
+	if g.Opening == OpeningSwap2 && len(g.OpeningChoices) == 0 {
+		return []OpeningChoice{ChoiceStay, ChoiceSwap, ChoicePlace}
+	}
+
+	return []OpeningChoice{ChoiceStay, ChoiceSwap}
+}
+
+func (g *Game) IsChoicePending() bool {
+	phase, _ := g.OpeningPhase()
+
+	return phase == PhaseChoose
+}
+
+func (g *Game) Swapped() bool {
+	swaps := 0
+	for _, c := range g.OpeningChoices {
+		if c == ChoiceSwap {
+			swaps++
+		}
+	}
+
+	return swaps%2 == 1
+}
+
+// SeatOf returns the seat which plays the color.
+func (g *Game) SeatOf(p Player) Seat {
+	if (p == PlayerX) != g.Swapped() {
+		return SeatFirst
+	}
+
+	return SeatSecond
+}
+
+// ColorOf returns the color the seat plays.
+func (g *Game) ColorOf(s Seat) Player {
+	if g.SeatOf(PlayerX) == s {
+		return PlayerX
+	}
+
+	return PlayerO
+}
+
+func (g *Game) ChooseOpening(c OpeningChoice) error {
+	options := g.OpeningOptions()
+	if options == nil {
+		return errors.New("there is no opening choice to make")
+	}
+
+	for _, o := range options {
+		if o == c {
+			g.OpeningChoices = append(g.OpeningChoices, c)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("opening choice %q is not available", c)
+}
+
+// ApplyOpening replays the moves of a game with an opening protocol, taking
+// the next choice whenever the protocol asks for one.
+func (g *Game) ApplyOpening(moves []int, choices []OpeningChoice) error {
+	rules := g.GetRules()
+
+	next := func() error {
+		for g.IsChoicePending() && len(choices) > 0 {
+			if err := g.ChooseOpening(choices[0]); err != nil {
+				return err
+			}
+			choices = choices[1:]
+		}
+
+		return nil
+	}
+
+	for _, move := range moves {
+		if err := next(); err != nil {
+			return err
+		}
+
+		if err := rules.Apply(g, move); err != nil {
+			return err
+		}
+	}
+
+	if err := next(); err != nil {
+		return err
+	}
+
+	if len(choices) > 0 {
+		return fmt.Errorf("unexpected opening choice %q", choices[0])
+	}
+
+	return nil
+}
+
+func (g *Game) openingTokens() []string {
+	if g.Opening == OpeningNone {
+		return nil
+	}
+
+	res := []string{"opening=" + g.Opening.String()}
+
+	if len(g.OpeningChoices) > 0 {
+		names := make([]string, len(g.OpeningChoices))
+		for i, c := range g.OpeningChoices {
+			names[i] = string(c)
+		}
+
+		res = append(res, "choices="+strings.Join(names, ","))
+	}
+
+	return res
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestPieRule(t *testing.T) {
+	game, _ := NewGame(3, 3)
+	game.Opening = OpeningPie
+
+	game.MakeMoveByIndex(4)
+
+	if phase, seat := game.OpeningPhase(); phase != PhaseChoose || seat != SeatSecond {
+		t.Fatalf("Expected the second seat to choose, got %s %s", phase, seat)
+	}
+
+	if err := game.GetRules().Apply(game, 0); err == nil {
+		t.Fatalf("Expected an error when moving before the choice")
+	}
+
+	if err := game.ChooseOpening(ChoiceSwap); err != nil {
+		t.Fatalf("Failed to swap: %v", err)
+	}
+
+	if game.ColorOf(SeatSecond) != PlayerX || game.SeatOf(game.PlayerTurn) != SeatFirst {
+		t.Fatalf("Expected the seats to be swapped")
+	}
+
+	if err := game.ChooseOpening(ChoiceSwap); err == nil {
+		t.Fatalf("Expected an error for a second swap")
+	}
+}
+
+func TestSwap2(t *testing.T) {
+	game, _ := NewGame(5, 4)
+	game.Opening = OpeningSwap2
+
+	err := game.ApplyOpening([]int{12, 13, 7, 8, 17}, []OpeningChoice{ChoicePlace, ChoiceSwap})
+	if err != nil {
+		t.Fatalf("Failed to apply the opening: %v", err)
+	}
+
+	if phase, seat := game.OpeningPhase(); phase != PhasePlay || seat != SeatFirst {
+		t.Fatalf("Expected the first seat to play O, got %s %s", phase, seat)
+	}
+
+	if game.ColorOf(SeatFirst) != PlayerO {
+		t.Fatalf("Expected the first seat to play O after the swap")
+	}
+
+	restored, err := FromString(game.String())
+	if err != nil {
+		t.Fatalf("Failed to create a new game from string: %v", err)
+	}
+
+	if restored.Opening != OpeningSwap2 || !restored.Swapped() || restored.IsChoicePending() {
+		t.Fatalf("Unexpected restored game %s", restored)
+	}
+}
@@ -0,0 +1,71 @@
+package game
+
+const CapturesToWin = 5
+
+var AllDirections = []Point{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {-1, -1}, {1, -1}, {-1, 1}}
+
+// GetCaptures returns the enemy stones which a stone of player p placed at i
+// would remove: exactly two enemy stones flanked by p on both sides.
+func (g *Game) GetCaptures(i int, p Player) []int {
+	var res []int
+
+	x, y := i%g.Size, i/g.Size
+	opponent := p.Opponent()
+
+	for _, d := range AllDirections {
+		if g.at(x+d.X, y+d.Y) == opponent &&
+			g.at(x+2*d.X, y+2*d.Y) == opponent &&
+			g.at(x+3*d.X, y+3*d.Y) == p {
+			res = append(res, x+d.X+(y+d.Y)*g.Size, x+2*d.X+(y+2*d.Y)*g.Size)
+		}
+	}
+
+	return res
+}
+
+// GetCaptureThreats returns the empty cells where player p can capture a
+// pair on the next move.
+func (g *Game) GetCaptureThreats(p Player) []int {
+	var res []int
+
+	for i, c := range g.Board {
+		if c == PlayerNone && len(g.GetCaptures(i, p)) > 0 {
+			res = append(res, i)
+		}
+	}
+
+	return res
+}
+
+func (g *Game) capture(i int) {
+	p := g.Board[i]
+	captured := g.GetCaptures(i, p)
+
+	for _, c := range captured {
+		g.Board[c] = PlayerNone
+	}
+
+	switch p {
+	case PlayerX:
+		g.CapturesX += len(captured) / 2
+	case PlayerO:
+		g.CapturesO += len(captured) / 2
+	}
+}
+
+func (g *Game) checkCaptureWin() {
+	switch {
+	case g.CapturesX >= CapturesToWin:
+		g.PlayerWon = PlayerX
+	case g.CapturesO >= CapturesToWin:
+		g.PlayerWon = PlayerO
+	}
+}
+
+func (g *Game) at(x, y int) Player {
+	if x < 0 || y < 0 || x >= g.Size || y >= g.Size {
+		return PlayerNone
+	}
+
+	return g.Board[x+y*g.Size]
+}
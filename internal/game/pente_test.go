@@ -0,0 +1,77 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestCapture(t *testing.T) {
+	game, _ := NewGame(5, 5)
+	PenteRules{}.Setup(game)
+
+	// X _ _ _ _    X O O X _
+	for _, i := range []int{0, 1, 20, 2, 3} {
+		if err := game.GetRules().Apply(game, i); err != nil {
+			t.Fatalf("Failed to make move %d: %v", i, err)
+		}
+	}
+
+	if game.Board[1] != PlayerNone || game.Board[2] != PlayerNone {
+		t.Fatalf("Expected captured stones to be removed, got %s", game.Board)
+	}
+
+	if game.CapturesX != 1 || game.CapturesO != 0 {
+		t.Fatalf("Expected captures 1:0, got %d:%d", game.CapturesX, game.CapturesO)
+	}
+
+	if game.PlayerTurn != PlayerO {
+		t.Fatalf("Expected PlayerO's turn, got %c", game.PlayerTurn)
+	}
+}
+
+func TestCaptureWin(t *testing.T) {
+	game, err := FromString("_ XOO______________________ turn=X cx=4 co=0")
+	if err != nil {
+		t.Fatalf("Failed to create a new game from string: %v", err)
+	}
+
+	if !game.Capture || game.CapturesX != 4 || game.StepsCount != 11 {
+		t.Fatalf("Unexpected capture state %v %d %d", game.Capture, game.CapturesX, game.StepsCount)
+	}
+
+	threats := game.GetCaptureThreats(PlayerX)
+	if len(threats) != 1 || threats[0] != 3 {
+		t.Fatalf("Expected a capture threat at 3, got %v", threats)
+	}
+
+	if err := game.GetRules().Apply(game, 3); err != nil {
+		t.Fatalf("Failed to make move: %v", err)
+	}
+
+	if game.PlayerWon != PlayerX {
+		t.Fatalf("Expected PlayerX to win by captures, got %c", game.PlayerWon)
+	}
+}
+
+func TestCaptureString(t *testing.T) {
+	str := "_ X__X_____________________ turn=O cx=1 co=2"
+
+	game, err := FromString(str)
+	if err != nil {
+		t.Fatalf("Failed to create a new game from string: %v", err)
+	}
+
+	if game.String() != str {
+		t.Fatalf("Expected game string to be %s, got %s", str, game.String())
+	}
+}
+
+func TestCaptureStringNegative(t *testing.T) {
+	for _, str := range []string{
+		"_ X__X_____________________ cx=-1",
+		"_ X__X_____________________ co=-3",
+	} {
+		if _, err := FromString(str); err == nil {
+			t.Fatalf("Expected an error for %s", str)
+		}
+	}
+}
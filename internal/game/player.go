@@ -10,6 +10,8 @@ const (
 	PlayerNone Player = '_'
 	PlayerX           = 'X'
 	PlayerO           = 'O'
+	// PlayerBlocked marks a cell which is not part of the board.
+	PlayerBlocked = '#'
 )
 
 func (p Player) Opponent() Player {
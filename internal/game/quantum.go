@@ -0,0 +1,373 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"tictactoe/internal/util"
+)
+
+// QuantumMark is a single mark of Goff's quantum tic-tac-toe. Until it is
+// collapsed it lives "spookily" in both Cells, afterwards Cell holds the
+// classical position.
+type QuantumMark struct {
+	Player Player
+	Cells  [2]int
+	Cell   int
+}
+
+func (m QuantumMark) IsClassical() bool {
+	return m.Cell >= 0
+}
+
+type QuantumGame struct {
+	PlayerTurn      Player
+	PlayerWon       Player
+	ScoreX          float64
+	ScoreO          float64
+	Size            int
+	WinLength       int
+	Marks           []QuantumMark
+	Board           []int
+	PendingCollapse int
+	History         []string
+}
+
+func NewQuantumGame(s, l int) (*QuantumGame, error) {
+	if s < 1 || s > MaxSize {
+		return nil, fmt.Errorf("invalid board size %d, must be between 1 and %d", s, MaxSize)
+	}
+
+	if l < 1 || l > s {
+		return nil, fmt.Errorf("invalid win length %d for board size %d", l, s)
+	}
+
+	g := &QuantumGame{
+		PlayerTurn: PlayerX,
+		PlayerWon:  PlayerNone,
+		Size:       s,
+		WinLength:  l,
+		Board:      make([]int, s*s),
+	}
+
+	return g, nil
+}
+
+func (g *QuantumGame) Copy() *QuantumGame {
+	newGame := *g
+
+	newGame.Marks = make([]QuantumMark, len(g.Marks))
+	copy(newGame.Marks, g.Marks)
+
+	newGame.Board = make([]int, len(g.Board))
+	copy(newGame.Board, g.Board)
+
+	newGame.History = make([]string, len(g.History))
+	copy(newGame.History, g.History)
+
+	return &newGame
+}
+
+func (g *QuantumGame) GetMapKey() string {
+	return util.GetMapKey(g.Size, g.WinLength)
+}
+
+// MakeSpookyMove places the current player's mark into cells a and b. When
+// only one cell is left a == b is allowed and the mark is classical at once.
+func (g *QuantumGame) MakeSpookyMove(a, b int) error {
+	if g.IsOver() {
+		return errors.New("game is already over")
+	}
+
+	if g.PendingCollapse != 0 {
+		return errors.New("a collapse has to be chosen first")
+	}
+
+	for _, i := range []int{a, b} {
+		if i < 0 || i >= len(g.Board) {
+			return fmt.Errorf("cell %d is out of the board", i)
+		}
+
+		if g.Board[i] != 0 {
+			return fmt.Errorf("cell %d is already classical", i)
+		}
+	}
+
+	free := g.FreeCells()
+
+	if a == b && len(free) != 1 {
+		return errors.New("spooky mark must occupy two different cells")
+	}
+
+	if a != b && len(free) == 1 {
+		return errors.New("only one cell is left, the last mark must be classical")
+	}
+
+	mark := QuantumMark{Player: g.PlayerTurn, Cells: [2]int{a, b}, Cell: -1}
+	cycle := a != b && g.isEntangled(a, b)
+
+	g.Marks = append(g.Marks, mark)
+	g.PlayerTurn = g.PlayerTurn.Opponent()
+
+	if a == b {
+		g.History = append(g.History, strconv.Itoa(a))
+		g.collapse(len(g.Marks), a)
+		return nil
+	}
+
+	g.History = append(g.History, fmt.Sprintf("%d-%d", a, b))
+
+	if cycle {
+		g.PendingCollapse = len(g.Marks)
+	}
+
+	return nil
+}
+
+// Collapse resolves the pending cycle by putting the mark which closed it into
+// the given cell. It is chosen by the player who did not create the cycle.
+func (g *QuantumGame) Collapse(cell int) error {
+	if g.PendingCollapse == 0 {
+		return errors.New("there is nothing to collapse")
+	}
+
+	mark := g.Marks[g.PendingCollapse-1]
+	if cell != mark.Cells[0] && cell != mark.Cells[1] {
+		return fmt.Errorf("mark %c%d can not collapse into cell %d", mark.Player, g.PendingCollapse, cell)
+	}
+
+	g.History = append(g.History, fmt.Sprintf("!%d", cell))
+	g.collapse(g.PendingCollapse, cell)
+	g.PendingCollapse = 0
+
+	return nil
+}
+
+// CollapseOptions returns the cells the pending mark can collapse into.
+func (g *QuantumGame) CollapseOptions() []int {
+	if g.PendingCollapse == 0 {
+		return nil
+	}
+
+	mark := g.Marks[g.PendingCollapse-1]
+
+	return []int{mark.Cells[0], mark.Cells[1]}
+}
+
+func (g *QuantumGame) FreeCells() []int {
+	var res []int
+
+	for i, m := range g.Board {
+		if m == 0 {
+			res = append(res, i)
+		}
+	}
+
+	return res
+}
+
+// SpookyMarks returns subscripts of all not collapsed marks in the cell.
+func (g *QuantumGame) SpookyMarks(cell int) []int {
+	var res []int
+
+	for i, m := range g.Marks {
+		if !m.IsClassical() && (m.Cells[0] == cell || m.Cells[1] == cell) {
+			res = append(res, i+1)
+		}
+	}
+
+	return res
+}
+
+func (g *QuantumGame) IsOver() bool {
+	return g.PlayerWon != PlayerNone || len(g.FreeCells()) == 0
+}
+
+// Cells describes every cell for clients: "X3" for a classical mark and a
+// list of spooky marks such as "x1o2" otherwise.
+func (g *QuantumGame) Cells() []string {
+	res := make([]string, len(g.Board))
+
+	for i, s := range g.Board {
+		if s != 0 {
+			res[i] = fmt.Sprintf("%c%d", g.Marks[s-1].Player, s)
+			continue
+		}
+
+		var sb strings.Builder
+		for _, s := range g.SpookyMarks(i) {
+			sb.WriteString(fmt.Sprintf("%s%d", strings.ToLower(string(g.Marks[s-1].Player)), s))
+		}
+
+		if sb.Len() == 0 {
+			sb.WriteByte(byte(PlayerNone))
+		}
+
+		res[i] = sb.String()
+	}
+
+	return res
+}
+
+// String returns the map key followed by the move notation: "a-b" for a
+// spooky move, "a" for a final classical move and "!a" for a collapse choice.
+func (g *QuantumGame) String() string {
+	return strings.Join(append([]string{g.GetMapKey()}, g.History...), " ")
+}
+
+func QuantumFromString(str string) (*QuantumGame, error) {
+	tokens := strings.Fields(str)
+	if len(tokens) == 0 {
+		return nil, errors.New("empty quantum game string")
+	}
+
+	s, l, err := util.ParseMapKey(tokens[0])
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := NewQuantumGame(s, l)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tokens[1:] {
+		if err := g.ApplyNotation(t); err != nil {
+			return nil, fmt.Errorf("invalid move %q: %v", t, err)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *QuantumGame) ApplyNotation(t string) error {
+	if strings.HasPrefix(t, "!") {
+		cell, err := strconv.Atoi(t[1:])
+		if err != nil {
+			return err
+		}
+
+		return g.Collapse(cell)
+	}
+
+	cells := strings.SplitN(t, "-", 2)
+
+	a, err := strconv.Atoi(cells[0])
+	if err != nil {
+		return err
+	}
+
+	b := a
+	if len(cells) == 2 {
+		if b, err = strconv.Atoi(cells[1]); err != nil {
+			return err
+		}
+	}
+
+	return g.MakeSpookyMove(a, b)
+}
+
+func (g *QuantumGame) isEntangled(a, b int) bool {
+	visited := map[int]bool{a: true}
+	queue := []int{a}
+
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+
+		if cell == b {
+			return true
+		}
+
+		for _, s := range g.SpookyMarks(cell) {
+			m := g.Marks[s-1]
+			next := m.Cells[0]
+			if next == cell {
+				next = m.Cells[1]
+			}
+
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return false
+}
+
+func (g *QuantumGame) collapse(subscript, cell int) {
+	type step struct{ subscript, cell int }
+	queue := []step{{subscript, cell}}
+
+	for len(queue) > 0 {
+		st := queue[0]
+		queue = queue[1:]
+
+		m := &g.Marks[st.subscript-1]
+		if m.IsClassical() {
+			continue
+		}
+
+		m.Cell = st.cell
+		g.Board[st.cell] = st.subscript
+
+		for _, s := range g.SpookyMarks(st.cell) {
+			other := g.Marks[s-1]
+			next := other.Cells[0]
+			if next == st.cell {
+				next = other.Cells[1]
+			}
+
+			queue = append(queue, step{s, next})
+		}
+	}
+
+	g.checkWin()
+}
+
+// checkWin scores the classical lines. When both players complete a line
+// during one collapse, the one whose line has the lower maximum subscript
+// earns a point and the other one half of a point.
+func (g *QuantumGame) checkWin() {
+	best := map[Player]int{}
+
+	for _, positions := range GetWinPositions(g.Size, g.WinLength) {
+		first := g.Board[positions[0]]
+		if first == 0 {
+			continue
+		}
+
+		player := g.Marks[first-1].Player
+		maxSubscript := 0
+		complete := true
+
+		for _, i := range positions {
+			s := g.Board[i]
+			if s == 0 || g.Marks[s-1].Player != player {
+				complete = false
+				break
+			}
+
+			maxSubscript = max(maxSubscript, s)
+		}
+
+		if complete && (best[player] == 0 || maxSubscript < best[player]) {
+			best[player] = maxSubscript
+		}
+	}
+
+	x, o := best[PlayerX], best[PlayerO]
+
+	switch {
+	case x != 0 && o != 0 && x < o:
+		g.ScoreX, g.ScoreO, g.PlayerWon = 1, 0.5, PlayerX
+	case x != 0 && o != 0:
+		g.ScoreX, g.ScoreO, g.PlayerWon = 0.5, 1, PlayerO
+	case x != 0:
+		g.ScoreX, g.PlayerWon = 1, PlayerX
+	case o != 0:
+		g.ScoreO, g.PlayerWon = 1, PlayerO
+	}
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestQuantumSpookyMove(t *testing.T) {
+	g, _ := NewQuantumGame(3, 3)
+
+	if err := g.MakeSpookyMove(0, 4); err != nil {
+		t.Fatalf("Failed to make a spooky move: %v", err)
+	}
+
+	if g.PlayerTurn != PlayerO {
+		t.Fatalf("Expected PlayerO's turn, got %c", g.PlayerTurn)
+	}
+
+	cells := g.Cells()
+	if cells[0] != "x1" || cells[4] != "x1" || cells[1] != "_" {
+		t.Fatalf("Unexpected cells %v", cells)
+	}
+
+	if err := g.MakeSpookyMove(3, 3); err == nil {
+		t.Fatalf("Expected an error for a one-cell move")
+	}
+}
+
+func TestQuantumCollapse(t *testing.T) {
+	g, err := QuantumFromString("3x3_3 0-4 4-8 0-8")
+	if err != nil {
+		t.Fatalf("Failed to create a quantum game from string: %v", err)
+	}
+
+	if g.PendingCollapse != 3 {
+		t.Fatalf("Expected mark 3 to close a cycle, got %d", g.PendingCollapse)
+	}
+
+	if err := g.MakeSpookyMove(1, 2); err == nil {
+		t.Fatalf("Expected an error when moving before a collapse")
+	}
+
+	if err := g.Collapse(8); err != nil {
+		t.Fatalf("Failed to collapse: %v", err)
+	}
+
+	expected := []string{"X1", "_", "_", "_", "O2", "_", "_", "_", "X3"}
+	for i, c := range g.Cells() {
+		if c != expected[i] {
+			t.Fatalf("Expected cells to be %v, got %v", expected, g.Cells())
+		}
+	}
+
+	if g.String() != "3x3_3 0-4 4-8 0-8 !8" {
+		t.Fatalf("Unexpected game string %s", g.String())
+	}
+}
+
+func TestQuantumWin(t *testing.T) {
+	g, err := QuantumFromString("3x3_3 0-3 1-4 3-6 4-7 6-0 !6")
+	if err != nil {
+		t.Fatalf("Failed to create a quantum game from string: %v", err)
+	}
+
+	if !g.IsOver() || g.PlayerWon != PlayerX {
+		t.Fatalf("Expected PlayerX to win, got %c", g.PlayerWon)
+	}
+
+	if g.ScoreX != 1 || g.ScoreO != 0 {
+		t.Fatalf("Expected score 1:0, got %v:%v", g.ScoreX, g.ScoreO)
+	}
+}
+
+func TestQuantumSimultaneousWin(t *testing.T) {
+	g, err := QuantumFromString("3x3_3 0-1 1-4 3-4 4-7 6-7 0-7 !7")
+	if err != nil {
+		t.Fatalf("Failed to create a quantum game from string: %v", err)
+	}
+
+	if !g.IsOver() || g.PlayerWon != PlayerX {
+		t.Fatalf("Expected PlayerX to win, got %c", g.PlayerWon)
+	}
+
+	if g.ScoreX != 1 || g.ScoreO != 0.5 {
+		t.Fatalf("Expected score 1:0.5, got %v:%v", g.ScoreX, g.ScoreO)
+	}
+}
+
+func TestQuantumSize(t *testing.T) {
+	for _, s := range []int{0, MaxSize + 1, 100000} {
+		if _, err := NewQuantumGame(s, 3); err == nil {
+			t.Fatalf("Expected an error for board size %d", s)
+		}
+	}
+
+	if _, err := NewQuantumGame(3, 4); err == nil {
+		t.Fatalf("Expected an error for a win length longer than the board")
+	}
+}
@@ -0,0 +1,195 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Ruleset is a variant of the game. Packages which play, enumerate or serve
+// games go through the ruleset of the game instead of its board, so a new
+// variant only has to implement this interface.
+type Ruleset interface {
+	Name() string
+	// Setup configures a game created by NewGame or FromString for the rules.
+	Setup(g *Game)
+	// LegalMoves returns the cells the player to move can play, in order.
+	LegalMoves(g *Game) []int
+	Apply(g *Game, move int) error
+	IsTerminal(g *Game) bool
+	// Outcome returns the winner of a terminal game, PlayerNone for a draw.
+	Outcome(g *Game) Player
+	FormatMove(g *Game, move int) string
+	ParseMove(g *Game, str string) (int, error)
+	// LineWinner returns the winner of a game in which the player completed
+	// a win position.
+	LineWinner(g *Game, p Player) Player
+	// Placement reports whether a move does nothing but place a stone of
+	// the player to move on an empty cell, so searches may make moves with
+	// MakeMoveByIndex and tell positions apart by their stones alone.
+	Placement() bool
+}
+
+var Rulesets = map[string]Ruleset{}
+
+func RegisterRuleset(r Ruleset) {
+	Rulesets[r.Name()] = r
+}
+
+func GetRuleset(name string) (Ruleset, error) {
+	if name == "" {
+		return StandardRules{}, nil
+	}
+
+	r, ok := Rulesets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown rules %q", name)
+	}
+
+	return r, nil
+}
+
+func RulesetNames() []string {
+	names := make([]string, 0, len(Rulesets))
+	for name := range Rulesets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func init() {
+	RegisterRuleset(StandardRules{})
+	RegisterRuleset(PenteRules{})
+}
+
+// StandardRules is k-in-a-row: players take turns placing a stone on any
+// empty cell, and the first one completing a win position wins.
+type StandardRules struct{}
+
+func (StandardRules) Name() string {
+	return "standard"
+}
+
+func (StandardRules) Setup(g *Game) {
+	g.Rules = StandardRules{}
+	g.Capture = false
+}
+
+func (StandardRules) LegalMoves(g *Game) []int {
+	if g.IsOver() || g.IsChoicePending() {
+		return nil
+	}
+
+	var res []int
+	for i, p := range g.Board {
+		if p == PlayerNone {
+			res = append(res, i)
+		}
+	}
+
+	return res
+}
+
+func (StandardRules) Apply(g *Game, move int) error {
+	if err := checkMove(g, move); err != nil {
+		return err
+	}
+
+	g.MakeMoveByIndex(move)
+
+	return nil
+}
+
+func checkMove(g *Game, move int) error {
+	if g.IsOver() {
+		return fmt.Errorf("game is already over before %s", g.FormatMove(move))
+	}
+
+	if g.IsChoicePending() {
+		return fmt.Errorf("an opening choice has to be made before %s", g.FormatMove(move))
+	}
+
+	if move < 0 || move >= len(g.Board) || g.Board[move] != PlayerNone {
+		return fmt.Errorf("cell %s is not empty", g.FormatMove(move))
+	}
+
+	return nil
+}
+
+func (StandardRules) IsTerminal(g *Game) bool {
+	return g.IsOver()
+}
+
+func (StandardRules) Outcome(g *Game) Player {
+	return g.PlayerWon
+}
+
+func (StandardRules) FormatMove(g *Game, move int) string {
+	return g.FormatMove(move)
+}
+
+func (StandardRules) ParseMove(g *Game, str string) (int, error) {
+	return g.ParseMove(str)
+}
+
+// LineWinner gives the game to the player who completed the line, or to the
+// opponent in a misère game.
+func (StandardRules) LineWinner(g *Game, p Player) Player {
+	if g.Misere {
+		return p.Opponent()
+	}
+
+	return p
+}
+
+func (StandardRules) Placement() bool {
+	return true
+}
+
+// PenteRules are the standard rules where flanking exactly two enemy stones
+// captures them and CapturesToWin captured pairs win the game.
+type PenteRules struct {
+	StandardRules
+}
+
+func (PenteRules) Name() string {
+	return "pente"
+}
+
+func (PenteRules) Setup(g *Game) {
+	g.Rules = PenteRules{}
+	g.Capture = true
+}
+
+// Apply removes the pairs the stone captures before the lines are checked,
+// CapturesToWin captured pairs win at once.
+func (PenteRules) Apply(g *Game, move int) error {
+	if err := checkMove(g, move); err != nil {
+		return err
+	}
+
+	g.placeStone(move)
+	g.capture(move)
+	g.checkCaptureWin()
+	g.settle()
+
+	return nil
+}
+
+func (PenteRules) Placement() bool {
+	return false
+}
+
+// GetRules returns the ruleset of the game, the standard one by default.
+func (g *Game) GetRules() Ruleset {
+	if g.Rules != nil {
+		return g.Rules
+	}
+
+	if g.Capture {
+		return PenteRules{}
+	}
+
+	return StandardRules{}
+}
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestGetRuleset(t *testing.T) {
+	rules, err := GetRuleset("pente")
+	if err != nil {
+		t.Fatalf("Failed to get ruleset: %v", err)
+	}
+
+	game, _ := NewGame(5, 5)
+	rules.Setup(game)
+
+	if !game.Capture || game.GetRules().Name() != "pente" {
+		t.Fatalf("Expected pente rules, got %s", game.GetRules().Name())
+	}
+
+	if _, err := GetRuleset("chess"); err == nil {
+		t.Fatalf("Expected an error for unknown rules")
+	}
+}
+
+func TestStandardRules(t *testing.T) {
+	game, _ := NewGame(3, 3)
+	rules := game.GetRules()
+
+	if len(rules.LegalMoves(game)) != 9 {
+		t.Fatalf("Expected 9 legal moves, got %v", rules.LegalMoves(game))
+	}
+
+	for _, i := range []int{0, 3, 1, 4, 2} {
+		if err := rules.Apply(game, i); err != nil {
+			t.Fatalf("Failed to apply move %d: %v", i, err)
+		}
+	}
+
+	if !rules.IsTerminal(game) || rules.Outcome(game) != PlayerX {
+		t.Fatalf("Expected PlayerX to win, got %c", rules.Outcome(game))
+	}
+
+	if rules.LegalMoves(game) != nil {
+		t.Fatalf("Expected no legal moves in a terminal game")
+	}
+
+	if err := rules.Apply(game, 5); err == nil {
+		t.Fatalf("Expected an error when moving after the game is over")
+	}
+}
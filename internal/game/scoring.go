@@ -0,0 +1,119 @@
+package game
+
+import (
+	"fmt"
+)
+
+type ScoringMode int
+
+const (
+	ScoringNone ScoringMode = iota
+	ScoringLines
+	ScoringWeighted
+)
+
+func (sm ScoringMode) String() string {
+	switch sm {
+	case ScoringNone:
+		return "none"
+	case ScoringLines:
+		return "lines"
+	case ScoringWeighted:
+		return "weighted"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseScoringMode(str string) (ScoringMode, error) {
+	for _, sm := range []ScoringMode{ScoringNone, ScoringLines, ScoringWeighted} {
+		if sm.String() == str {
+			return sm, nil
+		}
+	}
+
+	return ScoringNone, fmt.Errorf("unknown scoring mode %q", str)
+}
+
+// updateScores counts the completed lines of every player. With
+// ScoringLines each completed win position is a point, so a run longer than
+// WinLength scores once per window. With ScoringWeighted every maximal run of
+// length n >= WinLength scores (n-WinLength+1)^2 points instead, along the
+// HexAxes on hex boards.
+func (g *Game) updateScores() {
+	g.ScoreX, g.ScoreO = 0, 0
+
+	if g.Scoring == ScoringWeighted && len(g.WinShapes) == 0 && len(g.Directions) == 0 {
+		g.updateWeightedScores()
+	} else {
+		for _, positions := range g.GetWinPositions() {
+			player := g.Board[positions[0]]
+			if player != PlayerX && player != PlayerO {
+				continue
+			}
+
+			complete := true
+			for _, i := range positions {
+				if g.Board[i] != player {
+					complete = false
+					break
+				}
+			}
+
+			if complete {
+				g.addScore(player, 1)
+			}
+		}
+	}
+
+	if g.IsFulfilled() {
+		switch {
+		case g.ScoreX > g.ScoreO:
+			g.PlayerWon = PlayerX
+		case g.ScoreO > g.ScoreX:
+			g.PlayerWon = PlayerO
+		default:
+			g.PlayerWon = PlayerNone
+		}
+	}
+}
+
+func (g *Game) updateWeightedScores() {
+	directions := Directions
+	if g.Geometry == GeometryHex {
+		directions = HexAxes
+	}
+
+	for i, player := range g.Board {
+		if player != PlayerX && player != PlayerO {
+			continue
+		}
+
+		x, y := i%g.Size, i/g.Size
+
+		for _, d := range directions {
+			if g.at(x-d.X, y-d.Y) == player {
+				continue
+			}
+
+			n := 1
+			for g.at(x+n*d.X, y+n*d.Y) == player {
+				n++
+			}
+
+			if n >= g.WinLength {
+				bonus := n - g.WinLength + 1
+				g.addScore(player, bonus*bonus)
+			}
+		}
+	}
+}
+
+func (g *Game) addScore(p Player, points int) {
+	switch p {
+	case PlayerX:
+		g.ScoreX += points
+	case PlayerO:
+		g.ScoreO += points
+	}
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestScoringLines(t *testing.T) {
+	game, _ := NewGame(4, 3)
+	game.Scoring = ScoringLines
+
+	// X X X X
+	// O O O _
+	for _, i := range []int{0, 4, 1, 5, 2, 6, 3} {
+		game.MakeMoveByIndex(i)
+	}
+
+	if game.ScoreX != 2 || game.ScoreO != 1 {
+		t.Fatalf("Expected score 2:1, got %d:%d", game.ScoreX, game.ScoreO)
+	}
+
+	if game.IsOver() {
+		t.Fatalf("Expected game to continue until the board is full")
+	}
+}
+
+func TestScoringWeighted(t *testing.T) {
+	game, _ := NewGame(4, 3)
+	game.Scoring = ScoringWeighted
+
+	for _, i := range []int{0, 4, 1, 5, 2, 6, 3} {
+		game.MakeMoveByIndex(i)
+	}
+
+	if game.ScoreX != 4 || game.ScoreO != 1 {
+		t.Fatalf("Expected score 4:1, got %d:%d", game.ScoreX, game.ScoreO)
+	}
+}
+
+func TestScoringWinner(t *testing.T) {
+	game, err := FromString("_ XXXOOOXOX scoring=lines")
+	if err != nil {
+		t.Fatalf("Failed to create a new game from string: %v", err)
+	}
+
+	if game.ScoreX != 1 || game.ScoreO != 1 || game.PlayerWon != PlayerNone {
+		t.Fatalf("Expected a 1:1 draw, got %d:%d won by %c", game.ScoreX, game.ScoreO, game.PlayerWon)
+	}
+
+	if game.GetMapKey() != "3x3_3_lines" {
+		t.Fatalf("Unexpected map key %s", game.GetMapKey())
+	}
+}
+
+func TestScoringWeightedHex(t *testing.T) {
+	game, _ := NewHexGame(2, 3)
+	game.Scoring = ScoringWeighted
+
+	// X takes the (1,1) diagonal, which is no hex axis, and a line along
+	// (1,-1) through the center
+	for _, i := range []int{6, 10, 12, 14, 18, 22, 16, 4, 8} {
+		game.MakeMoveByIndex(i)
+	}
+
+	if game.ScoreX != 1 || game.ScoreO != 0 {
+		t.Fatalf("Expected score 1:0, got %d:%d", game.ScoreX, game.ScoreO)
+	}
+}
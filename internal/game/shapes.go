@@ -0,0 +1,198 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"tictactoe/internal/util"
+)
+
+// Shape is a set of cell offsets which wins the game when a player occupies
+// every one of them, e.g. a polyomino of an achievement game.
+type Shape []Point
+
+var ShapePresets = map[string]string{
+	"domino":      "##",
+	"square":      "##,##",
+	"l-tetromino": "#.,#.,##",
+	"t-tetromino": "###,.#.",
+	"s-tetromino": ".##,##.",
+	"i-tetromino": "####",
+	"cross":       ".#.,###,.#.",
+}
+
+// ParseShape reads a preset name or a diagram where rows are separated by
+// commas, "#" marks a cell of the shape and "." an empty one: "#.,#.,##".
+func ParseShape(str string) (Shape, error) {
+	if preset, ok := ShapePresets[str]; ok {
+		str = preset
+	}
+
+	var sh Shape
+
+	for y, row := range strings.Split(str, ",") {
+		for x, c := range row {
+			switch c {
+			case '#':
+				sh = append(sh, Point{x, y})
+			case '.':
+			default:
+				return nil, fmt.Errorf("invalid shape symbol %q", c)
+			}
+		}
+	}
+
+	if len(sh) == 0 {
+		return nil, fmt.Errorf("shape %q is empty", str)
+	}
+
+	return sh.normalize(), nil
+}
+
+func (sh Shape) String() string {
+	w, h := sh.dimensions()
+	rows := make([]string, h)
+
+	for y := 0; y < h; y++ {
+		row := []byte(strings.Repeat(".", w))
+		for _, p := range sh {
+			if p.Y == y {
+				row[p.X] = '#'
+			}
+		}
+		rows[y] = string(row)
+	}
+
+	return strings.Join(rows, ",")
+}
+
+func (sh Shape) Rotate() Shape {
+	res := make(Shape, len(sh))
+	for i, p := range sh {
+		res[i] = Point{-p.Y, p.X}
+	}
+
+	return res.normalize()
+}
+
+func (sh Shape) Reflect() Shape {
+	res := make(Shape, len(sh))
+	for i, p := range sh {
+		res[i] = Point{-p.X, p.Y}
+	}
+
+	return res.normalize()
+}
+
+// Variants returns the distinct orientations of the shape.
+func (sh Shape) Variants(rotations, reflections bool) []Shape {
+	res := []Shape{sh.normalize()}
+
+	add := func(v Shape) {
+		for _, r := range res {
+			if r.String() == v.String() {
+				return
+			}
+		}
+		res = append(res, v)
+	}
+
+	if reflections {
+		add(res[0].Reflect())
+	}
+
+	if rotations {
+		for i := 0; i < len(res); i++ {
+			v := res[i]
+			for r := 0; r < 3; r++ {
+				v = v.Rotate()
+				add(v)
+			}
+		}
+	}
+
+	return res
+}
+
+func ShapesKey(shapes []Shape) string {
+	keys := make([]string, len(shapes))
+	for i, sh := range shapes {
+		keys[i] = sh.String()
+	}
+
+	return strings.Join(keys, "+")
+}
+
+// GetShapeWinPositions returns every placement of the shapes on the board.
+func GetShapeWinPositions(s int, shapes []Shape) [][]int {
+	cacheKey := util.GetMapKey(s, 0) + "_" + ShapesKey(shapes)
+
+	if cached := cachedWinPositions(cacheKey); cached != nil {
+		return cached
+	}
+
+	var res [][]int
+	seen := map[string]bool{}
+
+	for _, sh := range shapes {
+		w, h := sh.dimensions()
+
+		for yOffset := 0; yOffset <= s-h; yOffset++ {
+			for xOffset := 0; xOffset <= s-w; xOffset++ {
+				var positions []int
+
+				for _, p := range sh {
+					positions = append(positions, (p.Y+yOffset)*s+p.X+xOffset)
+				}
+
+				slices.Sort(positions)
+
+				key := fmt.Sprint(positions)
+				if seen[key] {
+					continue
+				}
+
+				seen[key] = true
+				res = append(res, positions)
+			}
+		}
+	}
+
+	cacheWinPositions(cacheKey, res)
+
+	return res
+}
+
+func (sh Shape) normalize() Shape {
+	if len(sh) == 0 {
+		return sh
+	}
+
+	minX, minY := sh[0].X, sh[0].Y
+	for _, p := range sh {
+		minX, minY = min(minX, p.X), min(minY, p.Y)
+	}
+
+	res := make(Shape, len(sh))
+	for i, p := range sh {
+		res[i] = Point{p.X - minX, p.Y - minY}
+	}
+
+	slices.SortFunc(res, func(a, b Point) int {
+		if a.Y != b.Y {
+			return a.Y - b.Y
+		}
+		return a.X - b.X
+	})
+
+	return res
+}
+
+func (sh Shape) dimensions() (int, int) {
+	w, h := 0, 0
+	for _, p := range sh {
+		w, h = max(w, p.X+1), max(h, p.Y+1)
+	}
+
+	return w, h
+}
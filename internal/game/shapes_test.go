@@ -0,0 +1,79 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestParseShape(t *testing.T) {
+	sh, err := ParseShape("l-tetromino")
+	if err != nil {
+		t.Fatalf("Failed to parse shape: %v", err)
+	}
+
+	if sh.String() != "#.,#.,##" {
+		t.Fatalf("Expected shape to be #.,#.,##, got %s", sh)
+	}
+
+	if _, err := ParseShape("#x"); err == nil {
+		t.Fatalf("Expected an error for an invalid shape")
+	}
+}
+
+func TestShapeVariants(t *testing.T) {
+	cases := map[string]int{
+		"square":      1,
+		"t-tetromino": 4,
+		"s-tetromino": 4,
+		"l-tetromino": 8,
+		"cross":       1,
+	}
+
+	for name, expected := range cases {
+		sh, _ := ParseShape(name)
+
+		if variants := sh.Variants(true, true); len(variants) != expected {
+			t.Fatalf("Expected %s to have %d variants, got %d", name, expected, len(variants))
+		}
+	}
+
+	sh, _ := ParseShape("l-tetromino")
+	if variants := sh.Variants(true, false); len(variants) != 4 {
+		t.Fatalf("Expected 4 rotations of l-tetromino, got %d", len(variants))
+	}
+}
+
+func TestGetShapeWinPositions(t *testing.T) {
+	sh, _ := ParseShape("square")
+	positions := GetShapeWinPositions(3, []Shape{sh})
+
+	expected := [][]int{{0, 1, 3, 4}, {1, 2, 4, 5}, {3, 4, 6, 7}, {4, 5, 7, 8}}
+	if len(positions) != len(expected) {
+		t.Fatalf("Expected win positions to be %v, got %v", expected, positions)
+	}
+
+	for i := range expected {
+		for j := range expected[i] {
+			if positions[i][j] != expected[i][j] {
+				t.Fatalf("Expected win positions to be %v, got %v", expected, positions)
+			}
+		}
+	}
+}
+
+func TestCheckWinWithShapes(t *testing.T) {
+	game, _ := NewGame(3, 3)
+	sh, _ := ParseShape("square")
+	game.SetWinShapes([]Shape{sh}, false, false)
+
+	for _, i := range []int{4, 0, 5, 6, 7, 2, 8} {
+		game.MakeMoveByIndex(i)
+	}
+
+	if game.PlayerWon != PlayerX {
+		t.Fatalf("Expected PlayerX to win, got %c", game.PlayerWon)
+	}
+
+	if game.GetMapKey() != "3x3_3_##,##" {
+		t.Fatalf("Unexpected map key %s", game.GetMapKey())
+	}
+}
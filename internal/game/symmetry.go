@@ -0,0 +1,139 @@
+package game
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Symmetries returns the symmetries of the board which keep its win
+// positions and blocked cells, as permutations where perm[i] is the cell i
+// is mapped to. Of the 8 symmetries of a square and the 12 of a hexagon
+// only those preserving the rules are left, and the identity is always the
+// first one.
+func (g *Game) Symmetries() [][]int {
+	var res [][]int
+	wins := winPositionsSet(g.GetWinPositions())
+
+	for _, transform := range g.transforms() {
+		perm := make([]int, len(g.Board))
+		valid := true
+
+		for i := range g.Board {
+			// the cells outside of a hexagon only fill up the square
+			if g.Geometry == GeometryHex && !isInHexagon(i%g.Size, i/g.Size, g.Size) {
+				perm[i] = i
+				continue
+			}
+
+			x, y := transform(i%g.Size, i/g.Size)
+			if x < 0 || y < 0 || x >= g.Size || y >= g.Size {
+				valid = false
+				break
+			}
+
+			perm[i] = x + y*g.Size
+
+			if (g.Board[i] == PlayerBlocked) != (g.Board[perm[i]] == PlayerBlocked) {
+				valid = false
+				break
+			}
+		}
+
+		if !valid || slices.ContainsFunc(res, func(p []int) bool { return slices.Equal(p, perm) }) {
+			continue
+		}
+
+		mapped := make([][]int, 0, len(wins))
+		for _, positions := range g.GetWinPositions() {
+			m := make([]int, len(positions))
+			for j, i := range positions {
+				m[j] = perm[i]
+			}
+			mapped = append(mapped, m)
+		}
+
+		if winPositionsSet(mapped) == wins {
+			res = append(res, perm)
+		}
+	}
+
+	return res
+}
+
+// CanonicalBoard returns the smallest board string among the symmetric
+// images of the position, equal for all positions of one symmetry class.
+func (g *Game) CanonicalBoard() string {
+	best := string(g.Board)
+	board := make([]Player, len(g.Board))
+
+	for _, perm := range g.Symmetries()[1:] {
+		for i, p := range g.Board {
+			board[perm[i]] = p
+		}
+
+		if str := string(board); str < best {
+			best = str
+		}
+	}
+
+	return best
+}
+
+func (g *Game) transforms() []func(x, y int) (int, int) {
+	n := g.Size - 1
+
+	if g.Geometry == GeometryHex {
+		c := g.Size / 2
+		res := make([]func(x, y int) (int, int), 0, 12)
+
+		for k := 0; k < 6; k++ {
+			for _, reflect := range []bool{false, true} {
+				res = append(res, func(x, y int) (int, int) {
+					q, r := x-c, y-c
+					if reflect {
+						q, r = r, q
+					}
+					// a rotation by 60 degrees maps (q, r) to (-r, q+r)
+					for i := 0; i < k; i++ {
+						q, r = -r, q+r
+					}
+					return q + c, r + c
+				})
+			}
+		}
+
+		return res
+	}
+
+	return []func(x, y int) (int, int){
+		func(x, y int) (int, int) { return x, y },
+		func(x, y int) (int, int) { return n - y, x },
+		func(x, y int) (int, int) { return n - x, n - y },
+		func(x, y int) (int, int) { return y, n - x },
+		func(x, y int) (int, int) { return n - x, y },
+		func(x, y int) (int, int) { return x, n - y },
+		func(x, y int) (int, int) { return y, x },
+		func(x, y int) (int, int) { return n - y, n - x },
+	}
+}
+
+func winPositionsSet(positions [][]int) string {
+	keys := make([]string, len(positions))
+
+	for i, p := range positions {
+		sorted := slices.Clone(p)
+		slices.Sort(sorted)
+
+		var sb strings.Builder
+		for _, j := range sorted {
+			sb.WriteString(strconv.Itoa(j) + " ")
+		}
+		keys[i] = sb.String()
+	}
+
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,31 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestSymmetries(t *testing.T) {
+	square, _ := NewGame(3, 3)
+	if n := len(square.Symmetries()); n != 8 {
+		t.Fatalf("Expected 8 symmetries of a square, got %d", n)
+	}
+
+	hex, _ := NewHexGame(2, 3)
+	if n := len(hex.Symmetries()); n != 12 {
+		t.Fatalf("Expected 12 symmetries of a hexagon, got %d", n)
+	}
+
+	rows, _ := FromString("_ _________ dirs=h")
+	if n := len(rows.Symmetries()); n != 4 {
+		t.Fatalf("Expected 4 symmetries with horizontal lines only, got %d", n)
+	}
+}
+
+func TestCanonicalBoard(t *testing.T) {
+	a, _ := FromString("_ X________")
+	b, _ := FromString("_ ________X")
+
+	if a.CanonicalBoard() != b.CanonicalBoard() {
+		t.Fatalf("Expected corners to share a canonical board, got %s and %s", a.CanonicalBoard(), b.CanonicalBoard())
+	}
+}
@@ -0,0 +1,220 @@
+package game
+
+import (
+	"fmt"
+)
+
+// ForcedDrawCells is the number of empty cells up to which adjudication
+// searches for forced draws.
+const ForcedDrawCells = 10
+
+// Termination is the reason a game has ended.
+type Termination int
+
+const (
+	TerminationNone Termination = iota
+	TerminationLine
+	TerminationCaptures
+	TerminationFullBoard
+	// TerminationDeadPosition is a draw as no win position can be completed
+	// by either player anymore.
+	TerminationDeadPosition
+	// TerminationForcedDraw is a draw as neither player can force a win.
+	TerminationForcedDraw
+	TerminationResignation
+	TerminationTimeout
+)
+
+func (t Termination) String() string {
+	switch t {
+	case TerminationNone:
+		return "none"
+	case TerminationLine:
+		return "line"
+	case TerminationCaptures:
+		return "captures"
+	case TerminationFullBoard:
+		return "full-board"
+	case TerminationDeadPosition:
+		return "dead-position"
+	case TerminationForcedDraw:
+		return "forced-draw"
+	case TerminationResignation:
+		return "resignation"
+	case TerminationTimeout:
+		return "timeout"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseTermination(str string) (Termination, error) {
+	for t := TerminationNone; t <= TerminationTimeout; t++ {
+		if t.String() == str {
+			return t, nil
+		}
+	}
+
+	return TerminationNone, fmt.Errorf("unknown termination %q", str)
+}
+
+func (t Termination) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// Termination returns why the game has ended, TerminationNone while it goes
+// on.
+func (g *Game) Termination() Termination {
+	switch {
+	case g.Ended != TerminationNone:
+		return g.Ended
+	case g.PlayerWon != PlayerNone && g.Capture && (g.CapturesX >= CapturesToWin || g.CapturesO >= CapturesToWin):
+		return TerminationCaptures
+	case g.PlayerWon != PlayerNone:
+		return TerminationLine
+	case g.IsFulfilled():
+		return TerminationFullBoard
+	default:
+		return TerminationNone
+	}
+}
+
+// Resign ends the game with a win of the opponent of p.
+func (g *Game) Resign(p Player) error {
+	return g.forfeit(p, TerminationResignation)
+}
+
+// Timeout ends the game with a win of the opponent of p, whose time is up.
+func (g *Game) Timeout(p Player) error {
+	return g.forfeit(p, TerminationTimeout)
+}
+
+func (g *Game) forfeit(p Player, t Termination) error {
+	if g.IsOver() {
+		return fmt.Errorf("game is already over")
+	}
+
+	if p != PlayerX && p != PlayerO {
+		return fmt.Errorf("invalid player %q", p)
+	}
+
+	g.PlayerWon = p.Opponent()
+	g.Ended = t
+
+	return nil
+}
+
+// IsDeadPosition reports whether no win position can be completed by either
+// player anymore. Under rules whose moves do more than place a stone, such as
+// captures, stones may leave the board, so such positions are never dead.
+func (g *Game) IsDeadPosition() bool {
+	if !g.GetRules().Placement() || g.Scoring != ScoringNone || g.PlayerWon != PlayerNone {
+		return false
+	}
+
+	for _, positions := range g.GetWinPositions() {
+		if _, ok := g.threatLine(positions, PlayerX); ok {
+			return false
+		}
+
+		if _, ok := g.threatLine(positions, PlayerO); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PerfectOutcome returns the winner under perfect play of both sides,
+// PlayerNone for a draw. Only positions with at most maxEmpty empty cells are
+// searched, ok is false for larger ones.
+func (g *Game) PerfectOutcome(maxEmpty int) (winner Player, ok bool) {
+	rules := g.GetRules()
+	if len(rules.LegalMoves(g)) > maxEmpty {
+		return PlayerNone, false
+	}
+
+	search := g.Copy()
+	search.Adjudicate = false
+
+	switch v := negamax(search, map[string]int{}); {
+	case v > 0:
+		return g.PlayerTurn, true
+	case v < 0:
+		return g.PlayerTurn.Opponent(), true
+	default:
+		return PlayerNone, true
+	}
+}
+
+// IsForcedDraw reports whether neither player can force a win in a position
+// small enough to be searched.
+func (g *Game) IsForcedDraw() bool {
+	if g.IsOver() {
+		return false
+	}
+
+	winner, ok := g.PerfectOutcome(ForcedDrawCells)
+
+	return ok && winner == PlayerNone
+}
+
+// adjudicate ends dead positions and forced draws as draws.
+func (g *Game) adjudicate() {
+	if g.IsOver() || g.Ended != TerminationNone {
+		return
+	}
+
+	if g.IsDeadPosition() {
+		g.Ended = TerminationDeadPosition
+	} else if g.IsForcedDraw() {
+		g.Ended = TerminationForcedDraw
+	}
+}
+
+// negamax returns 1 when the player to move wins, -1 when it loses and 0
+// for a draw.
+func negamax(g *Game, memo map[string]int) int {
+	rules := g.GetRules()
+
+	if rules.IsTerminal(g) {
+		switch rules.Outcome(g) {
+		case PlayerNone:
+			return 0
+		case g.PlayerTurn:
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	key := g.String()
+	if v, ok := memo[key]; ok {
+		return v
+	}
+
+	best := -1
+	for _, i := range rules.LegalMoves(g) {
+		next := g.Copy()
+		if err := rules.Apply(next, i); err != nil {
+			panic(err)
+		}
+
+		// a turn of several stones keeps the side to move
+		v := negamax(next, memo)
+		if next.PlayerTurn != g.PlayerTurn {
+			v = -v
+		}
+
+		if v > best {
+			best = v
+			if best == 1 {
+				break
+			}
+		}
+	}
+
+	memo[key] = best
+
+	return best
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestDeadPosition(t *testing.T) {
+	game, err := FromString("_ XOXXOOOX_")
+	if err != nil {
+		t.Fatalf("Failed to parse the game: %v", err)
+	}
+
+	if !game.IsDeadPosition() {
+		t.Fatalf("Expected a dead position")
+	}
+
+	if game.IsOver() {
+		t.Fatalf("Expected the game to go on without adjudication")
+	}
+
+	game.Adjudicate = true
+	game.CheckWin()
+
+	if !game.IsOver() || game.Termination() != TerminationDeadPosition {
+		t.Fatalf("Expected a dead position, got %s", game.Termination())
+	}
+
+	if game.GetRules().LegalMoves(game) != nil {
+		t.Fatalf("Expected no legal moves after the adjudication")
+	}
+}
+
+func TestForcedDraw(t *testing.T) {
+	game, _ := NewGame(3, 3)
+
+	if game.IsDeadPosition() {
+		t.Fatalf("Expected the empty board to be alive")
+	}
+
+	if !game.IsForcedDraw() {
+		t.Fatalf("Expected the empty board to be a forced draw")
+	}
+
+	game.MakeMoveByIndex(4)
+	game.MakeMoveByIndex(1)
+
+	if winner, ok := game.PerfectOutcome(ForcedDrawCells); !ok || winner != PlayerX {
+		t.Fatalf("Expected X to win after an edge reply, got %c", winner)
+	}
+
+	game.Adjudicate = true
+	game.CheckWin()
+
+	if game.IsOver() {
+		t.Fatalf("Expected a won position to go on")
+	}
+}
+
+func TestPerfectOutcomeStonesPerTurn(t *testing.T) {
+	game, _ := NewGame(4, 3)
+	game.Directions = []Point{{1, 0}, {0, 1}}
+	game.SetTurnOrder(PlayerX, 2)
+
+	if err := game.PlayMoves("a1 b1 a4 b4"); err != nil {
+		t.Fatalf("Failed to play moves: %v", err)
+	}
+
+	// X places two stones, the first one of them completes a1 b1 c1
+	if winner, ok := game.PerfectOutcome(16); !ok || winner != PlayerX {
+		t.Fatalf("Expected X to win, got %c", winner)
+	}
+}
+
+func TestTermination(t *testing.T) {
+	game, _ := NewGame(3, 3)
+
+	if game.Termination() != TerminationNone {
+		t.Fatalf("Expected no termination, got %s", game.Termination())
+	}
+
+	game.ApplyMoves([]int{0, 3, 1, 4, 2})
+
+	if game.Termination() != TerminationLine {
+		t.Fatalf("Expected a line, got %s", game.Termination())
+	}
+
+	game, _ = NewGame(3, 3)
+	game.ApplyMoves([]int{0, 4, 8, 2, 6, 3, 5, 7, 1})
+
+	if game.Termination() != TerminationFullBoard {
+		t.Fatalf("Expected a full board, got %s", game.Termination())
+	}
+}
+
+func TestResign(t *testing.T) {
+	game, _ := NewGame(3, 3)
+	game.MakeMoveByIndex(4)
+
+	if err := game.Resign(PlayerO); err != nil {
+		t.Fatalf("Failed to resign: %v", err)
+	}
+
+	if game.PlayerWon != PlayerX || game.Termination() != TerminationResignation {
+		t.Fatalf("Expected X to win by resignation, got %c %s", game.PlayerWon, game.Termination())
+	}
+
+	if err := game.Timeout(PlayerX); err == nil {
+		t.Fatalf("Expected an error after the game is over")
+	}
+
+	parsed, err := FromString(game.String())
+	if err != nil {
+		t.Fatalf("Failed to parse %q: %v", game.String(), err)
+	}
+
+	if parsed.PlayerWon != PlayerX || parsed.Termination() != TerminationResignation {
+		t.Fatalf("Expected the resignation to survive %q", game.String())
+	}
+}
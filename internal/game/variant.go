@@ -0,0 +1,200 @@
+package game
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// LineDirections are the directions of straight win lines by name, the
+// first letter of a name is used in game strings.
+var LineDirections = map[string]Point{
+	"horizontal":    {1, 0},
+	"vertical":      {0, 1},
+	"diagonal":      {1, 1},
+	"anti-diagonal": {-1, 1},
+}
+
+func ParseDirection(str string) (Point, error) {
+	for name, d := range LineDirections {
+		if str == name || str == name[:1] {
+			return d, nil
+		}
+	}
+
+	return Point{}, fmt.Errorf("unknown direction %q", str)
+}
+
+func FormatDirection(d Point) string {
+	for name, dir := range LineDirections {
+		if d == dir {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("%d,%d", d.X, d.Y)
+}
+
+// filterWinPositions drops the win positions which cross a blocked cell and,
+// when Directions are set, the straight lines running along other directions.
+func (g *Game) filterWinPositions(all [][]int) [][]int {
+	blocked := slices.Contains(g.Board, PlayerBlocked)
+	lines := len(g.Directions) > 0 && len(g.WinShapes) == 0
+
+	if !blocked && !lines {
+		return all
+	}
+
+	res := make([][]int, 0, len(all))
+
+	for _, positions := range all {
+		if blocked && slices.ContainsFunc(positions, func(i int) bool { return g.Board[i] == PlayerBlocked }) {
+			continue
+		}
+
+		if lines && len(positions) > 1 && !slices.Contains(g.Directions, g.lineDirection(positions)) {
+			continue
+		}
+
+		res = append(res, positions)
+	}
+
+	return res
+}
+
+func (g *Game) lineDirection(positions []int) Point {
+	a, b := positions[0], positions[1]
+
+	return Point{b%g.Size - a%g.Size, b/g.Size - a/g.Size}
+}
+
+// turnAt returns the player to move after the given number of stones when
+// FirstPlayer starts and every turn places StonesPerTurn stones.
+func (g *Game) turnAt(steps int) Player {
+	first := g.FirstPlayer
+	if first != PlayerO {
+		first = PlayerX
+	}
+
+	if (steps/max(g.StonesPerTurn, 1))%2 == 0 {
+		return first
+	}
+
+	return first.Opponent()
+}
+
+// SetTurnOrder makes first the starting player and lets every turn place
+// the given number of stones, the side to move is updated accordingly.
+func (g *Game) SetTurnOrder(first Player, stonesPerTurn int) {
+	g.FirstPlayer = first
+	g.StonesPerTurn = stonesPerTurn
+
+	if g.hasTurnOrder() {
+		g.PlayerTurn = g.turnAt(g.StepsCount)
+	}
+}
+
+// hasTurnOrder reports whether the side to move can not be derived by
+// simply alternating the players.
+func (g *Game) hasTurnOrder() bool {
+	return g.StonesPerTurn > 1 || g.FirstPlayer == PlayerO
+}
+
+// VariantTokens returns the game string tokens of the variant settings.
+func (g *Game) VariantTokens() []string {
+	var res []string
+
+	if g.FirstPlayer == PlayerO {
+		res = append(res, "first=O")
+	}
+
+	if g.StonesPerTurn > 1 {
+		res = append(res, "stones="+strconv.Itoa(g.StonesPerTurn))
+	}
+
+	if g.Misere {
+		res = append(res, "misere=1")
+	}
+
+	if len(g.Directions) > 0 {
+		names := make([]string, len(g.Directions))
+		for i, d := range g.Directions {
+			names[i] = FormatDirection(d)[:1]
+		}
+
+		res = append(res, "dirs="+strings.Join(names, ","))
+	}
+
+	return res
+}
+
+// variantKey extends the map key with the variant settings, blocked cells
+// are identified by a hash of their positions.
+func (g *Game) variantKey() string {
+	var parts []string
+
+	if g.FirstPlayer == PlayerO {
+		parts = append(parts, "o")
+	}
+
+	if g.StonesPerTurn > 1 {
+		parts = append(parts, "s"+strconv.Itoa(g.StonesPerTurn))
+	}
+
+	if g.Misere {
+		parts = append(parts, "misere")
+	}
+
+	if len(g.Directions) > 0 {
+		var dirs string
+		for _, d := range g.Directions {
+			dirs += FormatDirection(d)[:1]
+		}
+
+		parts = append(parts, dirs)
+	}
+
+	if slices.Contains(g.Board, PlayerBlocked) {
+		h := fnv.New32a()
+		for i, p := range g.Board {
+			if p == PlayerBlocked {
+				h.Write([]byte(strconv.Itoa(i) + ","))
+			}
+		}
+
+		parts = append(parts, fmt.Sprintf("b%08x", h.Sum32()))
+	}
+
+	return strings.Join(parts, "_")
+}
+
+func (g *Game) parseVariantToken(key, value string) (bool, error) {
+	var err error
+
+	switch key {
+	case "first":
+		if value != string(PlayerX) && value != string(PlayerO) {
+			return true, fmt.Errorf("invalid first player %q", value)
+		}
+		g.FirstPlayer = Player(value[0])
+	case "stones":
+		g.StonesPerTurn, err = strconv.Atoi(value)
+	case "misere":
+		g.Misere = value == "1"
+	case "dirs":
+		g.Directions = nil
+		for _, name := range strings.Split(value, ",") {
+			d, err := ParseDirection(name)
+			if err != nil {
+				return true, err
+			}
+			g.Directions = append(g.Directions, d)
+		}
+	default:
+		return false, nil
+	}
+
+	return true, err
+}
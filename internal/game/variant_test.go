@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestMisere(t *testing.T) {
+	game, err := FromString("_ XX_OO____ misere=1")
+	if err != nil {
+		t.Fatalf("Failed to create a new game from string: %v", err)
+	}
+
+	game.MakeMoveByIndex(2)
+
+	if game.PlayerWon != PlayerO {
+		t.Fatalf("Expected PlayerO to win a misère game, got %c", game.PlayerWon)
+	}
+
+	if line := game.GetWinLine(); len(line) != 3 || line[0] != 0 {
+		t.Fatalf("Expected the line of PlayerX, got %v", line)
+	}
+}
+
+func TestBlockedCells(t *testing.T) {
+	game, err := FromString("_ XX#______")
+	if err != nil {
+		t.Fatalf("Failed to create a new game from string: %v", err)
+	}
+
+	if game.PlayerTurn != PlayerO || game.StepsCount != 2 {
+		t.Fatalf("Expected blocked cells not to count as stones, got %c %d", game.PlayerTurn, game.StepsCount)
+	}
+
+	if n := len(game.GetWinPositions()); n != 5 {
+		t.Fatalf("Expected 5 win positions without the blocked cell, got %d", n)
+	}
+
+	game.Board[2] = PlayerX
+	game.CheckWin()
+
+	if game.PlayerWon != PlayerX {
+		t.Fatalf("Expected PlayerX to win, got %c", game.PlayerWon)
+	}
+}
+
+func TestDirections(t *testing.T) {
+	game, err := FromString("_ X___X___X dirs=h,v")
+	if err != nil {
+		t.Fatalf("Failed to create a new game from string: %v", err)
+	}
+
+	game.CheckWin()
+
+	if game.PlayerWon != PlayerNone {
+		t.Fatalf("Expected diagonals not to count, got %c", game.PlayerWon)
+	}
+
+	if n := len(game.GetWinPositions()); n != 6 {
+		t.Fatalf("Expected 6 win positions, got %d", n)
+	}
+}
+
+func TestTurnOrder(t *testing.T) {
+	game, _ := NewGame(4, 4)
+	game.SetTurnOrder(PlayerO, 2)
+
+	var turns []Player
+	for i := 0; i < 5; i++ {
+		turns = append(turns, game.PlayerTurn)
+		game.MakeMoveByIndex(i)
+	}
+
+	if string(turns) != "OOXXO" {
+		t.Fatalf("Expected turns OOXXO, got %s", string(turns))
+	}
+
+	restored, err := FromString(game.String())
+	if err != nil {
+		t.Fatalf("Failed to create a new game from string: %v", err)
+	}
+
+	if restored.PlayerTurn != PlayerO || restored.GetMapKey() != "4x4_4_o_s2" {
+		t.Fatalf("Unexpected restored game %c %s", restored.PlayerTurn, restored.GetMapKey())
+	}
+}
@@ -1,27 +1,45 @@
 package game
 
 import (
+	"sync"
 	"tictactoe/internal/util"
 )
 
+// WinPositionsCache keeps the generated lines by board, searches running in
+// parallel share it behind winPositionsMu.
 var WinPositionsCache = map[string][][]int{}
+var winPositionsMu sync.RWMutex
+
+func cachedWinPositions(key string) [][]int {
+	winPositionsMu.RLock()
+	defer winPositionsMu.RUnlock()
+
+	return WinPositionsCache[key]
+}
+
+func cacheWinPositions(key string, positions [][]int) {
+	winPositionsMu.Lock()
+	defer winPositionsMu.Unlock()
+
+	WinPositionsCache[key] = positions
+}
 
 func GetWinPositions(s, l int) [][]int {
 	cacheKey := util.GetMapKey(s, l)
 
-	if WinPositionsCache[cacheKey] != nil {
-		return WinPositionsCache[cacheKey]
+	if cached := cachedWinPositions(cacheKey); cached != nil {
+		return cached
 	}
 
 	res := make([][]int, 0, s*s)
 
 	// Vertical
-	for xOffset := 0; xOffset <= s-l; xOffset++ {
-		for x := 0; x < l; x++ {
+	for yOffset := 0; yOffset <= s-l; yOffset++ {
+		for x := 0; x < s; x++ {
 			var column []int
 
 			for y := 0; y < l; y++ {
-				column = append(column, (y*s)+(x+xOffset))
+				column = append(column, (y+yOffset)*s+x)
 			}
 
 			res = append(res, column)
@@ -29,12 +47,12 @@ func GetWinPositions(s, l int) [][]int {
 	}
 
 	// Horizontal
-	for yOffset := 0; yOffset <= s-l; yOffset++ {
-		for y := 0; y < l; y++ {
+	for xOffset := 0; xOffset <= s-l; xOffset++ {
+		for y := 0; y < s; y++ {
 			var row []int
 
 			for x := 0; x < l; x++ {
-				row = append(row, (y+yOffset)*s+x)
+				row = append(row, y*s+x+xOffset)
 			}
 
 			res = append(res, row)
@@ -57,7 +75,7 @@ func GetWinPositions(s, l int) [][]int {
 		}
 	}
 
-	WinPositionsCache[cacheKey] = res
+	cacheWinPositions(cacheKey, res)
 
 	return res
 }
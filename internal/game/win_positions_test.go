@@ -1,6 +1,8 @@
 package game
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"tictactoe/internal/util"
 )
@@ -48,3 +50,52 @@ func TestGetWinPositions(t *testing.T) {
 		t.Fatalf("Expected cache to contain key %s", cacheKey)
 	}
 }
+
+func TestGetWinPositionsShorterThanBoard(t *testing.T) {
+	winPositions := GetWinPositions(4, 3)
+
+	if len(winPositions) != 24 {
+		t.Fatalf("Expected 24 win positions, got %d", len(winPositions))
+	}
+
+	seen := map[string]bool{}
+	for _, positions := range winPositions {
+		key := fmt.Sprint(positions)
+		if seen[key] {
+			t.Fatalf("Expected win positions to be unique, got %v twice", positions)
+		}
+		seen[key] = true
+	}
+}
+
+func TestGetWinPositionsCoverBoard(t *testing.T) {
+	// the lines of the lower rows and right columns were missing when the
+	// win length was shorter than the board
+	expected := [][]int{{4, 8, 12}, {7, 11, 15}, {13, 14, 15}, {5, 10, 15}, {7, 10, 13}}
+
+	for _, line := range expected {
+		found := false
+		for _, positions := range GetWinPositions(4, 3) {
+			if fmt.Sprint(positions) == fmt.Sprint(line) {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Fatalf("Expected win positions to contain %v", line)
+		}
+	}
+}
+
+func TestGetWinPositionsConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for s := 3; s <= 12; s++ {
+		wg.Add(3)
+		go func() { defer wg.Done(); GetWinPositions(s, 3) }()
+		go func() { defer wg.Done(); GetHexWinPositions(s, 2) }()
+		go func() { defer wg.Done(); GetShapeWinPositions(s, []Shape{{{0, 0}, {1, 1}}}) }()
+	}
+
+	wg.Wait()
+}
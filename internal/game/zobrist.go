@@ -0,0 +1,56 @@
+package game
+
+import (
+	"hash/fnv"
+)
+
+// Salts of the Zobrist keys besides the stones, out of the range of the
+// stone keys of any board.
+const (
+	zobristTurn  = 1 << 40
+	zobristSteps = 2 << 40
+	zobristCX    = 3 << 40
+	zobristCO    = 4 << 40
+)
+
+// Hash returns the Zobrist hash of the position, equal for equal positions
+// under equal rules. It is mixed with the map key, so positions of
+// different board sizes, win lengths and variants do not share hashes.
+func (g *Game) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(g.GetMapKey()))
+	res := h.Sum64()
+
+	for i, p := range g.Board {
+		switch p {
+		case PlayerX:
+			res ^= zobristKey(uint64(i) << 1)
+		case PlayerO:
+			res ^= zobristKey(uint64(i)<<1 | 1)
+		}
+	}
+
+	if g.PlayerTurn == PlayerO {
+		res ^= zobristKey(zobristTurn)
+	}
+
+	if g.hasTurnOrder() {
+		res ^= zobristKey(zobristSteps + uint64(g.StepsCount))
+	}
+
+	if g.Capture {
+		res ^= zobristKey(zobristCX+uint64(g.CapturesX)) ^ zobristKey(zobristCO+uint64(g.CapturesO))
+	}
+
+	return res
+}
+
+// zobristKey returns the pseudo random key of a feature, a splitmix64 step,
+// so no key table has to be sized for the largest board.
+func zobristKey(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+
+	return x ^ x>>31
+}
@@ -0,0 +1,30 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	a, _ := NewGame(3, 3)
+	a.ApplyMoves([]int{0, 4, 8})
+
+	b, _ := NewGame(3, 3)
+	b.ApplyMoves([]int{8, 4, 0})
+
+	if a.Hash() != b.Hash() {
+		t.Fatalf("Expected transpositions to have equal hashes")
+	}
+
+	c, _ := NewGame(3, 2)
+	c.ApplyMoves([]int{0, 4, 8})
+
+	if a.Hash() == c.Hash() {
+		t.Fatalf("Expected another win length to change the hash")
+	}
+
+	b.MakeMoveByIndex(1)
+
+	if a.Hash() == b.Hash() {
+		t.Fatalf("Expected a move to change the hash")
+	}
+}
@@ -0,0 +1,149 @@
+package heuristic
+
+import (
+	"errors"
+	"math"
+	"tictactoe/internal/game"
+)
+
+const (
+	scoreWin     = 1 << 30
+	scoreBlock   = 1 << 26
+	scoreCapture = 1 << 12
+	scoreExposed = 1 << 10
+)
+
+// BestMove returns the cell with the highest ScoreMove for the player whose
+// turn it is.
+func BestMove(g *game.Game) (int, error) {
+	windows := CellWindows(g)
+	best, bestScore := -1, math.MinInt
+
+	for i, c := range g.Board {
+		if c != game.PlayerNone {
+			continue
+		}
+
+		if score := ScoreMove(g, windows, i); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	if best < 0 {
+		return 0, errors.New("no moves left")
+	}
+
+	return best, nil
+}
+
+// CellWindows returns the win positions every cell belongs to.
+func CellWindows(g *game.Game) [][][]int {
+	res := make([][][]int, len(g.Board))
+
+	for _, positions := range g.GetWinPositions() {
+		for _, i := range positions {
+			res[i] = append(res[i], positions)
+		}
+	}
+
+	return res
+}
+
+// ScoreMove rates a move of the current player by the lines it extends and
+// blocks, and under capture rules by the pairs it captures, saves or exposes.
+func ScoreMove(g *game.Game, windows [][][]int, i int) int {
+	p := g.PlayerTurn
+	o := p.Opponent()
+	score := 0
+
+	for _, positions := range windows[i] {
+		own, opponent := 0, 0
+
+		for _, j := range positions {
+			switch g.Board[j] {
+			case p:
+				own++
+			case o:
+				opponent++
+			}
+		}
+
+		switch {
+		case opponent == 0 && own == len(positions)-1:
+			score += scoreWin
+		case own == 0 && opponent == len(positions)-1:
+			score += scoreBlock
+		case opponent == 0:
+			score += 1 << (2 * own)
+		case own == 0:
+			score += 1 << (2*opponent - 1)
+		}
+	}
+
+	if g.Capture {
+		score += scoreCaptures(g, i, p)
+	}
+
+	return score
+}
+
+func scoreCaptures(g *game.Game, i int, p game.Player) int {
+	o := p.Opponent()
+	score := 0
+
+	captures := len(g.GetCaptures(i, p)) / 2
+	if captures > 0 && capturesOf(g, p)+captures >= game.CapturesToWin {
+		return scoreWin
+	}
+
+	score += captures * scoreCapture
+
+	if threats := len(g.GetCaptures(i, o)) / 2; threats > 0 {
+		if capturesOf(g, o)+threats >= game.CapturesToWin {
+			score += scoreBlock
+		}
+		score += threats * scoreCapture / 2
+	}
+
+	if isExposed(g, i, p) {
+		score -= scoreExposed
+	}
+
+	return score
+}
+
+// isExposed reports whether a stone of p placed at i forms a pair which the
+// opponent can capture on the next move.
+func isExposed(g *game.Game, i int, p game.Player) bool {
+	o := p.Opponent()
+	x, y := i%g.Size, i/g.Size
+
+	for _, d := range game.AllDirections {
+		if at(g, x+d.X, y+d.Y) != p {
+			continue
+		}
+
+		before, after := at(g, x-d.X, y-d.Y), at(g, x+2*d.X, y+2*d.Y)
+		if (before == o && after == game.PlayerNone) || (before == game.PlayerNone && after == o) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func capturesOf(g *game.Game, p game.Player) int {
+	if p == game.PlayerX {
+		return g.CapturesX
+	}
+
+	return g.CapturesO
+}
+
+func at(g *game.Game, x, y int) game.Player {
+	if x < 0 || y < 0 || x >= g.Size || y >= g.Size {
+		return 0
+	}
+
+	return g.Board[x+y*g.Size]
+}
@@ -0,0 +1,46 @@
+package heuristic
+
+import (
+	"testing"
+	"tictactoe/internal/game"
+)
+
+func TestBestMoveWins(t *testing.T) {
+	g, _ := game.FromString("_ XX_OO_X__")
+
+	move, err := BestMove(g)
+	if err != nil {
+		t.Fatalf("Failed to get the best move: %v", err)
+	}
+
+	if move != 5 {
+		t.Fatalf("Expected the winning move 5, got %d", move)
+	}
+}
+
+func TestBestMoveCapturesToWin(t *testing.T) {
+	g, _ := game.FromString("_ XOO______________________ turn=X cx=4 co=0")
+
+	move, err := BestMove(g)
+	if err != nil {
+		t.Fatalf("Failed to get the best move: %v", err)
+	}
+
+	if move != 3 {
+		t.Fatalf("Expected the capturing move 3, got %d", move)
+	}
+}
+
+func TestOpeningChoice(t *testing.T) {
+	g, _ := game.FromString("_ XX_O_____ opening=swap")
+	g.WinLength = 3
+
+	choice, err := OpeningChoice(g)
+	if err != nil {
+		t.Fatalf("Failed to make the opening choice: %v", err)
+	}
+
+	if choice != game.ChoiceSwap {
+		t.Fatalf("Expected to swap into the stronger X, got %s", choice)
+	}
+}
@@ -0,0 +1,101 @@
+package heuristic
+
+import (
+	"errors"
+	"math"
+	"tictactoe/internal/game"
+)
+
+// balanceMargin is the evaluation within which a Swap2 position counts as
+// balanced enough to place two more stones instead of choosing a color.
+const balanceMargin = 4
+
+// Evaluate rates the position for PlayerX: every win position which only
+// one player occupies adds 4^stones for X and subtracts it for O.
+func Evaluate(g *game.Game) int {
+	score := 0
+
+	for _, positions := range g.GetWinPositions() {
+		x, o := 0, 0
+
+		for _, i := range positions {
+			switch g.Board[i] {
+			case game.PlayerX:
+				x++
+			case game.PlayerO:
+				o++
+			}
+		}
+
+		switch {
+		case o == 0 && x > 0:
+			score += 1 << (2 * x)
+		case x == 0 && o > 0:
+			score -= 1 << (2 * o)
+		}
+	}
+
+	return score
+}
+
+// BalancedMove returns the cell which leaves the position closest to even,
+// it is used to place opening stones the opponent may take over.
+func BalancedMove(g *game.Game) (int, error) {
+	best, bestScore := -1, math.MaxInt
+
+	for i, c := range g.Board {
+		if c != game.PlayerNone {
+			continue
+		}
+
+		next := g.Copy()
+		if err := next.GetRules().Apply(next, i); err != nil {
+			return 0, err
+		}
+
+		score := Evaluate(next)
+		if score < 0 {
+			score = -score
+		}
+
+		if next.PlayerWon != game.PlayerNone {
+			score = scoreWin
+		}
+
+		if score < bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	if best < 0 {
+		return 0, errors.New("no moves left")
+	}
+
+	return best, nil
+}
+
+// OpeningChoice decides a pending opening choice for the seat to move: it
+// takes the color the evaluation favours, or in Swap2 places two more stones
+// when the position is balanced.
+func OpeningChoice(g *game.Game) (game.OpeningChoice, error) {
+	options := g.OpeningOptions()
+	if options == nil {
+		return "", errors.New("there is no opening choice to make")
+	}
+
+	_, seat := g.OpeningPhase()
+	score := Evaluate(g)
+	if g.ColorOf(seat) == game.PlayerO {
+		score = -score
+	}
+
+	if len(options) == 3 && score >= -balanceMargin && score <= balanceMargin {
+		return game.ChoicePlace, nil
+	}
+
+	if score < 0 {
+		return game.ChoiceSwap, nil
+	}
+
+	return game.ChoiceStay, nil
+}
@@ -40,9 +40,21 @@ func NewMapBuilder() *MapBuilder {
 }
 
 func (mb *MapBuilder) BuildWinMap(g *game.Game) error {
+	if g.Capture {
+		return fmt.Errorf("maps can not be built for capture rules")
+	}
+
+	if g.ExpandMargin > 0 {
+		return fmt.Errorf("maps can not be built for expanding boards")
+	}
+
+	if phase, _ := g.OpeningPhase(); phase != game.PhasePlay {
+		return fmt.Errorf("maps can not be built during the opening")
+	}
+
 	map_storage.SaveProgress(g, 0)
 
-	if g.IsOver() {
+	if g.GetRules().IsTerminal(g) {
 		return fmt.Errorf("game is already over")
 	}
 
@@ -59,7 +71,7 @@ type GetMapStatusResponse struct {
 func (mb *MapBuilder) buildWinMapWorker() {
 	for g := range mb.buildWinMapChan {
 		start := time.Now()
-		fmt.Println("building win map started", g)
+		fmt.Printf("building win map started %s\n%s", g.GetMapKey(), g.Diagram(game.DiagramOptions{}))
 
 		mb.stats.Reset()
 		mb.stats.BuildStarted(g)
@@ -86,7 +98,7 @@ func (mb *MapBuilder) buildWinMapWorker() {
 		stopped = true
 
 		mb.stats.Print(start)
-		fmt.Println("building win map finished in", time.Since(start), g)
+		fmt.Printf("building win map finished in %s %s\n%s", time.Since(start), g.GetMapKey(), g.Diagram(game.DiagramOptions{}))
 
 		map_storage.RemoveDuplicates(g)
 		map_storage.SaveProgress(g, 100)
@@ -98,10 +110,13 @@ func (mb *MapBuilder) todoWorker() {
 		mb.stats.GameStarted()
 
 		g := task.game.Copy()
+		rules := g.GetRules()
 
-		g.MakeMoveByIndex(task.move)
+		if err := rules.Apply(g, task.move); err != nil {
+			panic(err)
+		}
 
-		if g.IsOver() {
+		if rules.IsTerminal(g) {
 			mb.saveResult(g)
 		} else {
 			task.wg.Add(1)
@@ -122,8 +137,8 @@ func (mb *MapBuilder) todoWorker() {
 
 func (mb *MapBuilder) doneWorker() {
 	for task := range mb.doneChan {
-		for i := task.move + 1; i < len(task.game.Board); i++ {
-			if task.game.Board[i] == game.PlayerNone {
+		for _, i := range task.game.GetRules().LegalMoves(task.game) {
+			if i > task.move {
 				task.wg.Add(1)
 				mb.todoChan <- Task{wg: task.wg, game: task.game, move: i}
 				break
@@ -2,6 +2,8 @@ package map_builder
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 	"sync/atomic"
 	"tictactoe/internal/game"
 	"tictactoe/internal/util"
@@ -13,6 +15,8 @@ type Stats struct {
 	gamesCountElapsed   uint64
 	gamesInProgress     uint64
 	games               StatsGames
+	scoresMu            sync.Mutex
+	scores              map[int]uint64
 }
 
 type StatsGames struct {
@@ -36,6 +40,10 @@ func (s *Stats) Reset() {
 	s.games.won = 0
 	s.games.lose = 0
 	s.games.draw = 0
+
+	s.scoresMu.Lock()
+	s.scores = map[int]uint64{}
+	s.scoresMu.Unlock()
 }
 
 func (s *Stats) GameStarted() {
@@ -56,7 +64,7 @@ func (s *Stats) GamePlayed(g *game.Game) {
 	atomic.AddUint64(&s.gamesCountElapsed, ^(c - 1))
 	atomic.AddUint64(&s.games.played, 1)
 
-	switch g.PlayerWon {
+	switch g.GetRules().Outcome(g) {
 	case game.PlayerX:
 		atomic.AddUint64(&s.games.won, 1)
 	case game.PlayerO:
@@ -64,6 +72,12 @@ func (s *Stats) GamePlayed(g *game.Game) {
 	case game.PlayerNone:
 		atomic.AddUint64(&s.games.draw, 1)
 	}
+
+	if g.Scoring != game.ScoringNone {
+		s.scoresMu.Lock()
+		s.scores[g.ScoreX-g.ScoreO]++
+		s.scoresMu.Unlock()
+	}
 }
 
 func (s *Stats) GetPercent() float64 {
@@ -81,4 +95,17 @@ func (s *Stats) Print(start time.Time) {
 	fmt.Println("games won:", atomic.LoadUint64(&s.games.won))
 	fmt.Println("games lose:", atomic.LoadUint64(&s.games.lose))
 	fmt.Println("games draw:", atomic.LoadUint64(&s.games.draw))
+
+	s.scoresMu.Lock()
+	defer s.scoresMu.Unlock()
+
+	diffs := make([]int, 0, len(s.scores))
+	for diff := range s.scores {
+		diffs = append(diffs, diff)
+	}
+	sort.Ints(diffs)
+
+	for _, diff := range diffs {
+		fmt.Printf("games with score difference %+d: %d\n", diff, s.scores[diff])
+	}
 }
@@ -19,9 +19,59 @@ type Task struct {
 }
 
 type Result struct {
-	Win  uint64 `json:"win"`
-	Lose uint64 `json:"lose"`
-	Draw uint64 `json:"draw"`
+	Win    uint64         `json:"win"`
+	Lose   uint64         `json:"lose"`
+	Draw   uint64         `json:"draw"`
+	Scores map[int]uint64 `json:"scores,omitempty"`
+}
+
+// ScoreMean returns the average difference between X and O scores of the
+// games in the distribution.
+func (r Result) ScoreMean() float64 {
+	var sum, count float64
+
+	for diff, n := range r.Scores {
+		sum += float64(diff) * float64(n)
+		count += float64(n)
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return sum / count
+}
+
+// forPlayer returns the result from the perspective of the given player,
+// swapping wins with losses and negating score differences for PlayerO.
+func (r Result) forPlayer(p game.Player) Result {
+	if p != game.PlayerO {
+		return r
+	}
+
+	res := Result{Win: r.Lose, Lose: r.Win, Draw: r.Draw}
+
+	for diff, n := range r.Scores {
+		if res.Scores == nil {
+			res.Scores = map[int]uint64{}
+		}
+		res.Scores[-diff] = n
+	}
+
+	return res
+}
+
+func (r *Result) add(other Result) {
+	r.Win += other.Win
+	r.Lose += other.Lose
+	r.Draw += other.Draw
+
+	for diff, n := range other.Scores {
+		if r.Scores == nil {
+			r.Scores = map[int]uint64{}
+		}
+		r.Scores[diff] += n
+	}
 }
 
 type MapReader struct {
@@ -63,15 +113,34 @@ func (mr *MapReader) checkFileWorker() {
 				continue
 			}
 
-			if util.CompareGamePattern(task.game.String()[2:], gameStr) {
-				switch winner {
-				case game.PlayerX:
-					res.Win++
-				case game.PlayerO:
-					res.Lose++
-				case game.PlayerNone:
-					res.Draw++
+			if !util.CompareGamePattern(string(task.game.Board), gameStr) {
+				continue
+			}
+
+			switch winner {
+			case game.PlayerX:
+				res.Win++
+			case game.PlayerO:
+				res.Lose++
+			case game.PlayerNone:
+				res.Draw++
+			}
+
+			if task.game.Scoring != game.ScoringNone {
+				g, err := game.FromString(line)
+				if err != nil {
+					fmt.Println("failed to parse line", line, err)
+					continue
 				}
+
+				g.WinLength = task.game.WinLength
+				g.WinShapes = task.game.WinShapes
+				g.CheckWin()
+
+				if res.Scores == nil {
+					res.Scores = map[int]uint64{}
+				}
+				res.Scores[g.ScoreX-g.ScoreO]++
 			}
 		}
 
@@ -88,8 +157,7 @@ func (mr *MapReader) checkFileWorker() {
 }
 
 func (mr *MapReader) GetGameStats(g *game.Game) (Result, error) {
-	gameStr := g.String()
-	pattern := gameStr[2 : len(gameStr)-6]
+	pattern := string(g.Board[:len(g.Board)-6])
 
 	paths, err := map_storage.GetChunkFiles(g)
 	if err != nil {
@@ -115,9 +183,7 @@ func (mr *MapReader) GetGameStats(g *game.Game) (Result, error) {
 
 	go func() {
 		for r := range resultChan {
-			res.Win += r.Win
-			res.Lose += r.Lose
-			res.Draw += r.Draw
+			res.add(r)
 			wg.Done()
 		}
 	}()
@@ -141,18 +207,18 @@ func (mr *MapReader) GetGameStats(g *game.Game) (Result, error) {
 func (mr *MapReader) GetNextMove(g *game.Game) (int, int, error) {
 	wg := &sync.WaitGroup{}
 	results := map[int]Result{}
+	rules := g.GetRules()
 
-	for i, p := range g.Board {
-		if p != game.PlayerNone {
-			continue
-		}
-
+	for _, i := range rules.LegalMoves(g) {
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
 
 			gCopy := g.Copy()
-			gCopy.Board[i] = game.PlayerX
+			if err := rules.Apply(gCopy, i); err != nil {
+				fmt.Println("failed to apply move", err)
+				return
+			}
 
 			res, err := mr.GetGameStats(gCopy)
 			if err != nil {
@@ -171,6 +237,18 @@ func (mr *MapReader) GetNextMove(g *game.Game) (int, int, error) {
 	var bestResult Result
 
 	for i, res := range results {
+		res = res.forPlayer(g.PlayerTurn)
+
+		if g.Scoring != game.ScoringNone {
+			if !haveResults || res.ScoreMean() > bestResult.ScoreMean() {
+				bestMove = i
+				bestResult = res
+			}
+
+			haveResults = true
+			continue
+		}
+
 		haveResults = true
 
 		if res.Win > bestResult.Win {
@@ -45,7 +45,7 @@ func GetChunkFiles(g *game.Game) ([]string, error) {
 	return filepath.Glob(
 		filepath.Join(
 			getChunksDir(),
-			util.GetMapKey(g.Size, g.WinLength),
+			g.GetMapKey(),
 			"*",
 		),
 	)
@@ -59,7 +59,7 @@ func IsMapExist(s, l int) bool {
 func getChunkFilePath(g *game.Game) string {
 	return filepath.Join(
 		getChunksDir(),
-		util.GetMapKey(g.Size, g.WinLength),
+		g.GetMapKey(),
 		string(g.Board[0:len(g.Board)-6]),
 	)
 }
@@ -77,7 +77,7 @@ func SaveProgress(g *game.Game, p uint8) {
 }
 
 func getRelevantProgressFile(g *game.Game) (string, error) {
-	files, err := filepath.Glob(getChunksDir() + "/progress/*")
+	files, err := filepath.Glob(filepath.Join(getChunksDir(), "progress", g.GetMapKey(), "*"))
 	if err != nil {
 		return "", err
 	}
@@ -95,6 +95,7 @@ func getProgressPath(g *game.Game) string {
 	return filepath.Join(
 		getChunksDir(),
 		"progress",
+		g.GetMapKey(),
 		g.String(),
 	)
 }
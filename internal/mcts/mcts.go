@@ -0,0 +1,403 @@
+package mcts
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"runtime"
+	"slices"
+	"sync"
+	"tictactoe/internal/game"
+	"tictactoe/internal/heuristic"
+	"tictactoe/internal/tss"
+	"time"
+)
+
+// neighbourhood is the distance to the nearest stone up to which cells are
+// expanded in the tree, which keeps the branching low on large boards.
+const neighbourhood = 2
+
+type Config struct {
+	// Exploration is the constant of the UCT formula, higher values try
+	// more moves and lower ones search the best moves deeper.
+	Exploration float64
+	// Duration and Playouts bound a search, a zero bound is not used.
+	Duration time.Duration
+	Playouts int
+	Workers  int
+	// Seed makes the searches of a single worker repeatable, zero seeds
+	// them at random.
+	Seed int64
+	// Heuristic playouts complete lines and block the lines of the opponent
+	// before playing at random, and the tree expands the moves the heuristic
+	// rates best first.
+	Heuristic bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Exploration: math.Sqrt2,
+		Duration:    time.Second,
+		Workers:     runtime.NumCPU(),
+		Heuristic:   true,
+	}
+}
+
+// Result is the move with the most visits of a search.
+type Result struct {
+	Move     int     `json:"move"`
+	Visits   int     `json:"visits"`
+	WinRate  float64 `json:"winRate"`
+	Playouts int     `json:"playouts"`
+	Nodes    int     `json:"nodes"`
+}
+
+type node struct {
+	move     int
+	player   game.Player
+	hash     uint64
+	parent   *node
+	children []*node
+	untried  []int
+	terminal bool
+	winner   game.Player
+	visits   float64
+	reward   float64
+}
+
+// Engine searches with UCT. The tree of a search is kept, and a later
+// search of a position reached from it by one or two moves continues with
+// the subtree of the position.
+type Engine struct {
+	config Config
+	search sync.Mutex
+	tree   sync.Mutex
+	root   *node
+	nodes  int
+	layout *layout
+	key    string
+}
+
+func New(c Config) *Engine {
+	if c.Workers < 1 {
+		c.Workers = 1
+	}
+
+	return &Engine{config: c}
+}
+
+// Search returns the best move of the position for the player to move.
+func (e *Engine) Search(g *game.Game) (Result, error) {
+	e.search.Lock()
+	defer e.search.Unlock()
+
+	rules := g.GetRules()
+	if rules.IsTerminal(g) {
+		return Result{}, errors.New("game is already over")
+	}
+
+	if e.config.Duration <= 0 && e.config.Playouts <= 0 {
+		return Result{}, errors.New("search has no budget")
+	}
+
+	if i, ok := forced(g); ok {
+		return Result{Move: i}, nil
+	}
+
+	if key := g.GetMapKey(); key != e.key {
+		e.key, e.root, e.layout = key, nil, newLayout(g)
+	}
+
+	e.reuse(g)
+	if e.root == nil {
+		e.nodes = 0
+		e.root = e.newNode(g, -1, g.PlayerTurn.Opponent(), nil)
+	}
+
+	var deadline time.Time
+	if e.config.Duration > 0 {
+		deadline = time.Now().Add(e.config.Duration)
+	}
+
+	seed := e.config.Seed
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+
+	var playouts int
+	wg := &sync.WaitGroup{}
+
+	for w := 0; w < e.config.Workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(seed))
+
+			for {
+				e.tree.Lock()
+				done := e.config.Playouts > 0 && playouts >= e.config.Playouts
+				playouts++
+				e.tree.Unlock()
+
+				if done || (!deadline.IsZero() && time.Now().After(deadline)) {
+					return
+				}
+
+				e.iterate(g, rng)
+			}
+		}(seed + int64(w))
+	}
+
+	wg.Wait()
+
+	best := e.bestChild()
+	if best == nil {
+		return Result{}, errors.New("no moves left")
+	}
+
+	return Result{
+		Move:     best.move,
+		Visits:   int(best.visits),
+		WinRate:  best.reward / best.visits,
+		Playouts: int(e.root.visits),
+		Nodes:    e.nodes,
+	}, nil
+}
+
+// Continues reports whether the tree of the engine can be reused for the
+// position, which is the case when it was reached from the last searched
+// position by up to two moves.
+func (e *Engine) Continues(g *game.Game) bool {
+	e.search.Lock()
+	defer e.search.Unlock()
+
+	if e.root == nil || g.GetMapKey() != e.key {
+		return false
+	}
+
+	return e.find(g.Hash()) != nil
+}
+
+// forced returns the move threats decide without a search: a win at once
+// or the block of a win of the opponent, and on boards with long lines the
+// moves of threat sequences.
+func forced(g *game.Game) (int, bool) {
+	if tss.Supported(g) {
+		return tss.BestMove(g)
+	}
+
+	if !g.GetRules().Placement() || g.StonesPerTurn > 1 {
+		return 0, false
+	}
+
+	moves := g.GetRules().LegalMoves(g)
+
+	for _, p := range []game.Player{g.PlayerTurn, g.PlayerTurn.Opponent()} {
+		for _, i := range moves {
+			next := g.Copy()
+			next.PlayerTurn = p
+			next.MakeMoveByIndex(i)
+
+			if next.PlayerWon == p {
+				return i, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// iterate selects a leaf, expands it, plays it out and backs the result up.
+// A visit is counted on the way down as a loss, so concurrent workers
+// spread over the tree, and the reward is added on the way up.
+func (e *Engine) iterate(root *game.Game, rng *rand.Rand) {
+	state := root.Copy()
+	state.Adjudicate = false
+	rules := state.GetRules()
+
+	e.tree.Lock()
+
+	n := e.root
+	n.visits++
+
+	for len(n.untried) == 0 && len(n.children) > 0 {
+		n = e.selectChild(n)
+		n.visits++
+
+		if err := rules.Apply(state, n.move); err != nil {
+			panic(err)
+		}
+	}
+
+	if len(n.untried) > 0 {
+		move := n.untried[len(n.untried)-1]
+		n.untried = n.untried[:len(n.untried)-1]
+		player := state.PlayerTurn
+
+		if err := rules.Apply(state, move); err != nil {
+			panic(err)
+		}
+
+		child := e.newNode(state, move, player, n)
+		n.children = append(n.children, child)
+		n = child
+		n.visits++
+	}
+
+	e.tree.Unlock()
+
+	winner := n.winner
+	if !n.terminal {
+		winner = e.playout(state, rng)
+	}
+
+	e.tree.Lock()
+	for ; n != nil; n = n.parent {
+		switch winner {
+		case n.player:
+			n.reward++
+		case game.PlayerNone:
+			n.reward += 0.5
+		}
+	}
+	e.tree.Unlock()
+}
+
+func (e *Engine) selectChild(n *node) *node {
+	var best *node
+	bestScore := math.Inf(-1)
+	logVisits := math.Log(n.visits)
+
+	for _, c := range n.children {
+		score := c.reward/c.visits + e.config.Exploration*math.Sqrt(logVisits/c.visits)
+		if score > bestScore {
+			best, bestScore = c, score
+		}
+	}
+
+	return best
+}
+
+func (e *Engine) bestChild() *node {
+	var best *node
+
+	for _, c := range e.root.children {
+		if best == nil || c.visits > best.visits {
+			best = c
+		}
+	}
+
+	return best
+}
+
+func (e *Engine) newNode(g *game.Game, move int, player game.Player, parent *node) *node {
+	e.nodes++
+
+	n := &node{move: move, player: player, hash: g.Hash(), parent: parent}
+
+	if rules := g.GetRules(); rules.IsTerminal(g) {
+		n.terminal, n.winner = true, rules.Outcome(g)
+		return n
+	}
+
+	n.untried = e.candidates(g)
+
+	return n
+}
+
+// candidates returns the moves to expand, near the stones on the board and
+// with the best rated last.
+func (e *Engine) candidates(g *game.Game) []int {
+	moves := g.GetRules().LegalMoves(g)
+	near := moves[:0:0]
+
+	for _, i := range moves {
+		if isNear(g, i) {
+			near = append(near, i)
+		}
+	}
+
+	if len(near) > 0 {
+		moves = near
+	}
+
+	if e.config.Heuristic {
+		scores := make(map[int]int, len(moves))
+		for _, i := range moves {
+			scores[i] = heuristic.ScoreMove(g, e.layout.cellPositions, i)
+		}
+
+		slices.SortStableFunc(moves, func(a, b int) int { return scores[a] - scores[b] })
+	} else {
+		rand.Shuffle(len(moves), func(a, b int) { moves[a], moves[b] = moves[b], moves[a] })
+	}
+
+	return moves
+}
+
+func isNear(g *game.Game, i int) bool {
+	x, y := i%g.Size, i/g.Size
+
+	for dy := -neighbourhood; dy <= neighbourhood; dy++ {
+		for dx := -neighbourhood; dx <= neighbourhood; dx++ {
+			nx, ny := x+dx, y+dy
+			if nx < 0 || ny < 0 || nx >= g.Size || ny >= g.Size {
+				continue
+			}
+
+			if p := g.Board[nx+ny*g.Size]; p == game.PlayerX || p == game.PlayerO {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// reuse keeps the subtree of the position when it was reached from the
+// last root by up to two moves, and recounts the nodes of the tree.
+func (e *Engine) reuse(g *game.Game) {
+	if e.root == nil {
+		return
+	}
+
+	e.root = e.find(g.Hash())
+	if e.root == nil {
+		return
+	}
+
+	e.root.parent = nil
+	e.nodes = count(e.root)
+}
+
+// find returns the node of the position among the root, its children and
+// its grandchildren.
+func (e *Engine) find(hash uint64) *node {
+	if e.root.hash == hash {
+		return e.root
+	}
+
+	for _, c := range e.root.children {
+		if c.hash == hash {
+			return c
+		}
+
+		for _, gc := range c.children {
+			if gc.hash == hash {
+				return gc
+			}
+		}
+	}
+
+	return nil
+}
+
+func count(n *node) int {
+	res := 1
+	for _, c := range n.children {
+		res += count(c)
+	}
+
+	return res
+}
@@ -0,0 +1,103 @@
+package mcts
+
+import (
+	"testing"
+	"tictactoe/internal/game"
+)
+
+func testConfig(playouts int) Config {
+	c := DefaultConfig()
+	c.Duration = 0
+	c.Playouts = playouts
+	c.Workers = 1
+	c.Seed = 1
+
+	return c
+}
+
+func TestSearchWins(t *testing.T) {
+	g, _ := game.NewGame(7, 5)
+	g.ApplyMoves([]int{24, 0, 25, 6, 26, 42, 27, 48})
+
+	res, err := New(testConfig(2000)).Search(g)
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	if res.Move != 23 && res.Move != 28 {
+		t.Fatalf("Expected the winning move 23 or 28, got %d", res.Move)
+	}
+}
+
+func TestSearchBlocks(t *testing.T) {
+	g, _ := game.NewGame(9, 5)
+	// X has an open three on the fourth row, O has to block it or lose
+	g.ApplyMoves([]int{30, 0, 31, 80, 32})
+
+	res, err := New(testConfig(4000)).Search(g)
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	// blocking one cell further away loses to an open four
+	if res.Move != 29 && res.Move != 33 {
+		t.Fatalf("Expected a block next to the three, got %d", res.Move)
+	}
+}
+
+func TestTreeReuse(t *testing.T) {
+	g, _ := game.NewGame(7, 5)
+	e := New(testConfig(1000))
+
+	first, err := e.Search(g)
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	reply := e.bestChild()
+	for _, c := range reply.children {
+		if c.visits > 1 {
+			reply = c
+			break
+		}
+	}
+
+	g.MakeMoveByIndex(first.Move)
+	g.MakeMoveByIndex(reply.move)
+
+	again, err := e.Search(g)
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	if again.Playouts <= 1000 {
+		t.Fatalf("Expected the playouts of the subtree to be kept, got %d", again.Playouts)
+	}
+
+	if again.Nodes != count(e.root) {
+		t.Fatalf("Expected %d nodes in the reused tree, got %d", count(e.root), again.Nodes)
+	}
+}
+
+func TestSearchForced(t *testing.T) {
+	g, _ := game.NewGame(7, 4)
+	// O has three in a column and X has to block it
+	g.ApplyMoves([]int{24, 0, 26, 7, 44, 14})
+
+	res, err := New(testConfig(100)).Search(g)
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	if res.Move != 21 || res.Playouts != 0 {
+		t.Fatalf("Expected the block 21 without a search, got %+v", res)
+	}
+}
+
+func TestSearchOver(t *testing.T) {
+	g, _ := game.FromString("X XXXOO____")
+
+	if _, err := New(testConfig(10)).Search(g); err == nil {
+		t.Fatalf("Expected an error for a finished game")
+	}
+}
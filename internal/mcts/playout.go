@@ -0,0 +1,165 @@
+package mcts
+
+import (
+	"math/rand"
+	"tictactoe/internal/game"
+	"tictactoe/internal/heuristic"
+)
+
+// layout is the win positions of a board, shared by the playouts of all
+// positions of it.
+type layout struct {
+	windows       [][]int
+	cellWindows   [][]int
+	cellPositions [][][]int
+}
+
+func newLayout(g *game.Game) *layout {
+	l := &layout{
+		windows:       g.GetWinPositions(),
+		cellWindows:   make([][]int, len(g.Board)),
+		cellPositions: heuristic.CellWindows(g),
+	}
+
+	for w, positions := range l.windows {
+		for _, i := range positions {
+			l.cellWindows[i] = append(l.cellWindows[i], w)
+		}
+	}
+
+	return l
+}
+
+// canPlayFast reports whether a playout can skip the rules, which holds for
+// the standard rules as long as the players take turns with single stones
+// and the first line decides.
+func canPlayFast(g *game.Game) bool {
+	return g.GetRules().Placement() && g.Scoring == game.ScoringNone && g.StonesPerTurn <= 1
+}
+
+// playout plays the game to the end and returns the winner.
+func (e *Engine) playout(g *game.Game, rng *rand.Rand) game.Player {
+	if canPlayFast(g) {
+		return e.layout.play(g, e.config.Heuristic && !g.Misere, rng)
+	}
+
+	rules := g.GetRules()
+
+	for !rules.IsTerminal(g) {
+		moves := rules.LegalMoves(g)
+		if err := rules.Apply(g, moves[rng.Intn(len(moves))]); err != nil {
+			panic(err)
+		}
+	}
+
+	return rules.Outcome(g)
+}
+
+// play counts the stones of both players in every win position, so a move
+// only looks at the positions through its cell. With smart play a player
+// completes an open position it lacks one stone of, or else blocks one of
+// the opponent, before playing at random.
+func (l *layout) play(g *game.Game, smart bool, rng *rand.Rand) game.Player {
+	board := make([]game.Player, len(g.Board))
+	copy(board, g.Board)
+
+	counts := [2][]int{make([]int, len(l.windows)), make([]int, len(l.windows))}
+	var threats [2][]int
+
+	var empty []int
+	for i, p := range board {
+		if p == game.PlayerNone {
+			empty = append(empty, i)
+		}
+	}
+
+	for w, positions := range l.windows {
+		for _, i := range positions {
+			if board[i] == game.PlayerX || board[i] == game.PlayerO {
+				counts[side(board[i])][w]++
+			}
+		}
+
+		for s := 0; s < 2; s++ {
+			if counts[s][w] == len(positions)-1 && counts[1-s][w] == 0 {
+				threats[s] = append(threats[s], w)
+			}
+		}
+	}
+
+	turn := g.PlayerTurn
+
+	for len(empty) > 0 {
+		s := side(turn)
+		cell := -1
+
+		if smart {
+			if cell = l.threatCell(board, counts, &threats[s], s); cell < 0 {
+				cell = l.threatCell(board, counts, &threats[1-s], 1-s)
+			}
+		}
+
+		k := rng.Intn(len(empty))
+		if cell >= 0 {
+			k = indexOf(empty, cell)
+		}
+
+		cell = empty[k]
+		empty[k] = empty[len(empty)-1]
+		empty = empty[:len(empty)-1]
+		board[cell] = turn
+
+		for _, w := range l.cellWindows[cell] {
+			counts[s][w]++
+
+			switch {
+			case counts[s][w] == len(l.windows[w]):
+				return g.GetRules().LineWinner(g, turn)
+			case counts[s][w] == len(l.windows[w])-1 && counts[1-s][w] == 0:
+				threats[s] = append(threats[s], w)
+			}
+		}
+
+		turn = turn.Opponent()
+	}
+
+	return game.PlayerNone
+}
+
+// threatCell returns the empty cell of a position the side lacks one stone
+// of, dropping the positions the other side has blocked on the way.
+func (l *layout) threatCell(board []game.Player, counts [2][]int, threats *[]int, s int) int {
+	for len(*threats) > 0 {
+		w := (*threats)[len(*threats)-1]
+
+		if counts[1-s][w] == 0 {
+			for _, i := range l.windows[w] {
+				if board[i] == game.PlayerNone {
+					return i
+				}
+			}
+		}
+
+		*threats = (*threats)[:len(*threats)-1]
+	}
+
+	return -1
+}
+
+func side(p game.Player) int {
+	if p == game.PlayerO {
+		return 1
+	}
+
+	return 0
+}
+
+func indexOf(cells []int, cell int) int {
+	for k, i := range cells {
+		if i == cell {
+			return k
+		}
+	}
+
+	return -1
+}
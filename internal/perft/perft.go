@@ -0,0 +1,163 @@
+package perft
+
+import (
+	"fmt"
+	"tictactoe/internal/game"
+)
+
+type Level struct {
+	Depth    int    `json:"depth"`
+	Nodes    uint64 `json:"nodes"`
+	Terminal uint64 `json:"terminal"`
+	XWon     uint64 `json:"xWon"`
+	OWon     uint64 `json:"oWon"`
+	Draw     uint64 `json:"draw"`
+}
+
+func (l Level) String() string {
+	return fmt.Sprintf("%5d %15d %15d %15d %15d %15d", l.Depth, l.Nodes, l.Terminal, l.XWon, l.OWon, l.Draw)
+}
+
+// References are published node counts of the empty boards, by map key.
+var References = map[string]struct {
+	Games     []Level
+	Positions []Level
+}{
+	"3x3_3": {
+		Games: []Level{
+			{Depth: 0, Nodes: 1},
+			{Depth: 1, Nodes: 9},
+			{Depth: 2, Nodes: 72},
+			{Depth: 3, Nodes: 504},
+			{Depth: 4, Nodes: 3024},
+			{Depth: 5, Nodes: 15120, Terminal: 1440, XWon: 1440},
+			{Depth: 6, Nodes: 54720, Terminal: 5328, OWon: 5328},
+			{Depth: 7, Nodes: 148176, Terminal: 47952, XWon: 47952},
+			{Depth: 8, Nodes: 200448, Terminal: 72576, OWon: 72576},
+			{Depth: 9, Nodes: 127872, Terminal: 127872, XWon: 81792, Draw: 46080},
+		},
+		Positions: []Level{
+			{Depth: 0, Nodes: 1},
+			{Depth: 1, Nodes: 9},
+			{Depth: 2, Nodes: 72},
+			{Depth: 3, Nodes: 252},
+			{Depth: 4, Nodes: 756},
+			{Depth: 5, Nodes: 1260, Terminal: 120, XWon: 120},
+			{Depth: 6, Nodes: 1520, Terminal: 148, OWon: 148},
+			{Depth: 7, Nodes: 1140, Terminal: 444, XWon: 444},
+			{Depth: 8, Nodes: 390, Terminal: 168, OWon: 168},
+			{Depth: 9, Nodes: 78, Terminal: 78, XWon: 62, Draw: 16},
+		},
+	},
+}
+
+// Total sums the levels, e.g. 255168 games and 5478 positions of 3x3.
+func Total(levels []Level) Level {
+	res := Level{Depth: len(levels) - 1}
+
+	for _, l := range levels {
+		res.Nodes += l.Nodes
+		res.Terminal += l.Terminal
+		res.XWon += l.XWon
+		res.OWon += l.OWon
+		res.Draw += l.Draw
+	}
+
+	return res
+}
+
+// Perft counts the nodes reachable from the game at every depth up to the
+// given one, or until every game is over when depth is negative. With merge
+// the transpositions are counted once, so the nodes are distinct positions
+// rather than move sequences.
+func Perft(g *game.Game, depth int, merge bool) []Level {
+	if merge {
+		return perftMerged(g, depth)
+	}
+
+	var levels []Level
+	perft(g, 0, depth, &levels)
+
+	return levels
+}
+
+func perft(g *game.Game, ply, depth int, levels *[]Level) {
+	rules := g.GetRules()
+	count(g, ply, levels)
+
+	if rules.IsTerminal(g) || ply == depth {
+		return
+	}
+
+	for _, i := range rules.LegalMoves(g) {
+		next := g.Copy()
+		if err := rules.Apply(next, i); err != nil {
+			panic(err)
+		}
+		perft(next, ply+1, depth, levels)
+	}
+}
+
+func perftMerged(g *game.Game, depth int) []Level {
+	var levels []Level
+	current := []*game.Game{g}
+
+	for ply := 0; len(current) > 0; ply++ {
+		next := map[string]*game.Game{}
+		var order []string
+
+		for _, pos := range current {
+			rules := pos.GetRules()
+			count(pos, ply, &levels)
+
+			if rules.IsTerminal(pos) || ply == depth {
+				continue
+			}
+
+			for _, i := range rules.LegalMoves(pos) {
+				child := pos.Copy()
+				if err := rules.Apply(child, i); err != nil {
+					panic(err)
+				}
+
+				key := child.String() + string(child.PlayerTurn)
+				if _, ok := next[key]; !ok {
+					next[key] = child
+					order = append(order, key)
+				}
+			}
+		}
+
+		current = current[:0]
+		for _, key := range order {
+			current = append(current, next[key])
+		}
+	}
+
+	return levels
+}
+
+func count(g *game.Game, ply int, levels *[]Level) {
+	for len(*levels) <= ply {
+		*levels = append(*levels, Level{Depth: len(*levels)})
+	}
+
+	l := &(*levels)[ply]
+	l.Nodes++
+
+	rules := g.GetRules()
+	if !rules.IsTerminal(g) {
+		return
+	}
+
+	l.Terminal++
+
+	switch rules.Outcome(g) {
+	case game.PlayerX:
+		l.XWon++
+	case game.PlayerO:
+		l.OWon++
+	default:
+		l.Draw++
+	}
+}
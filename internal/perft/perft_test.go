@@ -0,0 +1,56 @@
+package perft
+
+import (
+	"testing"
+	"tictactoe/internal/game"
+	"tictactoe/internal/util"
+)
+
+func TestPerftReferences(t *testing.T) {
+	for key, ref := range References {
+		for _, merge := range []bool{false, true} {
+			size, win, _ := util.ParseMapKey(key)
+			g, _ := game.NewGame(size, win)
+			levels := Perft(g, -1, merge)
+
+			expected := ref.Games
+			if merge {
+				expected = ref.Positions
+			}
+
+			if len(levels) != len(expected) {
+				t.Fatalf("%s: expected %d levels, got %d", key, len(expected), len(levels))
+			}
+
+			for i := range expected {
+				if levels[i] != expected[i] {
+					t.Fatalf("%s (merge %v): expected\n%v\ngot\n%v", key, merge, expected[i], levels[i])
+				}
+			}
+		}
+	}
+
+	if total := Total(References["3x3_3"].Games); total.Terminal != 255168 {
+		t.Fatalf("Expected 255168 games, got %d", total.Terminal)
+	}
+
+	if total := Total(References["3x3_3"].Positions); total.Nodes != 5478 {
+		t.Fatalf("Expected 5478 positions, got %d", total.Nodes)
+	}
+}
+
+func TestPerftDepth(t *testing.T) {
+	g, _ := game.NewGame(4, 4)
+	levels := Perft(g, 2, false)
+
+	if len(levels) != 3 || levels[2].Nodes != 240 {
+		t.Fatalf("Expected 240 nodes at depth 2, got %v", levels)
+	}
+}
+
+func BenchmarkPerft3x3(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		g, _ := game.NewGame(3, 3)
+		Perft(g, -1, false)
+	}
+}
@@ -0,0 +1,116 @@
+package pns
+
+import (
+	"errors"
+	"tictactoe/internal/game"
+	"time"
+)
+
+// DefaultMaxEntries bounds the table of a prover, about 40 MB.
+const DefaultMaxEntries = 1 << 20
+
+// infinity is the proof or disproof number of a decided position.
+const infinity = 1 << 40
+
+type Value string
+
+const (
+	ValueUnknown Value = "unknown"
+	ValueWin     Value = "win"
+	ValueDraw    Value = "draw"
+	ValueLoss    Value = "loss"
+)
+
+type Config struct {
+	MaxEntries int
+	// MaxNodes and Duration bound a run, a zero bound is not used. A run
+	// which stops at a bound can be resumed.
+	MaxNodes uint64
+	Duration time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{MaxEntries: DefaultMaxEntries}
+}
+
+// Solver proves the value of a position for the player to move with two
+// df-pn searches: whether the player can force a win, and if not, whether
+// the opponent can.
+type Solver struct {
+	config Config
+	root   *game.Game
+	// provers are the searches for wins of the player to move and of the
+	// opponent.
+	provers [2]*prover
+	nodes   uint64
+	start   uint64
+	stop    time.Time
+}
+
+func New(g *game.Game, c Config) (*Solver, error) {
+	if g.GetRules().IsTerminal(g) {
+		return nil, errors.New("game is already over")
+	}
+
+	if c.MaxEntries <= 0 {
+		c.MaxEntries = DefaultMaxEntries
+	}
+
+	s := &Solver{config: c, root: g.Copy()}
+	s.root.Adjudicate = false
+
+	for k, p := range []game.Player{g.PlayerTurn, g.PlayerTurn.Opponent()} {
+		s.provers[k] = newProver(s, p)
+	}
+
+	return s, nil
+}
+
+// Nodes returns the number of positions expanded by all runs.
+func (s *Solver) Nodes() uint64 {
+	return s.nodes
+}
+
+// Run continues the proof until the value is known or a bound is reached,
+// in which case it is ValueUnknown.
+func (s *Solver) Run() Value {
+	if s.config.Duration > 0 {
+		s.stop = time.Now().Add(s.config.Duration)
+	} else {
+		s.stop = time.Time{}
+	}
+
+	s.start = s.nodes
+
+	for k, p := range s.provers {
+		for !p.decided() && !s.exhausted() {
+			p.mid(s.root, infinity, infinity)
+		}
+
+		switch {
+		case !p.decided():
+			return ValueUnknown
+		case p.proved() && k == 0:
+			return ValueWin
+		case p.proved():
+			return ValueLoss
+		}
+	}
+
+	return ValueDraw
+}
+
+// exhausted reports whether the run has reached a bound, which stops the
+// search on its way back to the root.
+func (s *Solver) exhausted() bool {
+	if s.config.MaxNodes > 0 && s.nodes-s.start >= s.config.MaxNodes {
+		return true
+	}
+
+	return !s.stop.IsZero() && time.Now().After(s.stop)
+}
+
+// Proof returns the proof of the root position with the value of a run.
+func (s *Solver) Proof(v Value) Proof {
+	return Proof{Game: s.root.String(), WinLength: s.root.WinLength, Value: v, Nodes: s.nodes}
+}
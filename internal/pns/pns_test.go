@@ -0,0 +1,91 @@
+package pns
+
+import (
+	"path/filepath"
+	"testing"
+	"tictactoe/internal/game"
+)
+
+func TestRun(t *testing.T) {
+	for _, c := range []struct {
+		game     string
+		size     int
+		win      int
+		expected Value
+	}{
+		{"", 3, 3, ValueDraw},
+		{"", 4, 3, ValueWin},
+		{"_ X_X_O_X__ turn=O", 3, 3, ValueLoss},
+	} {
+		g, _ := game.NewGame(c.size, c.win)
+		if c.game != "" {
+			g, _ = game.FromString(c.game)
+		}
+
+		s, err := New(g, DefaultConfig())
+		if err != nil {
+			t.Fatalf("Failed to create a solver: %v", err)
+		}
+
+		if v := s.Run(); v != c.expected {
+			t.Fatalf("Expected %s for %dx%d_%d %q, got %s", c.expected, c.size, c.size, c.win, c.game, v)
+		}
+	}
+}
+
+func TestSmallTable(t *testing.T) {
+	g, _ := game.NewGame(3, 3)
+
+	s, _ := New(g, Config{MaxEntries: 200})
+	if v := s.Run(); v != ValueDraw {
+		t.Fatalf("Expected a draw with a small table, got %s", v)
+	}
+
+	for _, p := range s.provers {
+		if len(p.table) > 200 {
+			t.Fatalf("Expected at most 200 entries, got %d", len(p.table))
+		}
+	}
+}
+
+func TestResume(t *testing.T) {
+	g, _ := game.NewGame(4, 3)
+	path := filepath.Join(t.TempDir(), "state")
+
+	s, _ := New(g, Config{MaxNodes: 100})
+	if v := s.Run(); v != ValueUnknown {
+		t.Fatalf("Expected the run to stop early, got %s", v)
+	}
+
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	resumed, err := Resume(path, DefaultConfig())
+	if err != nil {
+		t.Fatalf("Failed to resume: %v", err)
+	}
+
+	if v := resumed.Run(); v != ValueWin || resumed.Nodes() <= 100 {
+		t.Fatalf("Expected a win after resuming, got %s after %d nodes", v, resumed.Nodes())
+	}
+}
+
+func TestProofs(t *testing.T) {
+	dir := t.TempDir()
+	g, _ := game.NewGame(3, 3)
+
+	if _, ok, err := GetProof(dir, g); ok || err != nil {
+		t.Fatalf("Expected no proof, got %v", err)
+	}
+
+	s, _ := New(g, DefaultConfig())
+	if err := SaveProof(dir, g, s.Proof(s.Run())); err != nil {
+		t.Fatalf("Failed to save the proof: %v", err)
+	}
+
+	p, ok, err := GetProof(dir, g)
+	if !ok || err != nil || p.Value != ValueDraw || p.WinLength != 3 {
+		t.Fatalf("Expected a proved draw, got %+v %v", p, err)
+	}
+}
@@ -0,0 +1,73 @@
+package pns
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"tictactoe/internal/game"
+)
+
+// Proof is a proved value of a position for the player to move.
+type Proof struct {
+	Game      string `json:"game"`
+	WinLength int    `json:"winLength"`
+	Value     Value  `json:"value"`
+	Nodes     uint64 `json:"nodes"`
+}
+
+// SaveProof adds the proof to the proofs of its board in the directory.
+func SaveProof(dir string, g *game.Game, p Proof) error {
+	proofs, err := readProofs(dir, g)
+	if err != nil {
+		return err
+	}
+
+	proofs[g.String()] = p
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	data, err := json.MarshalIndent(proofs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(proofsPath(dir, g), data, 0644)
+}
+
+// GetProof returns the proof of the position from the directory.
+func GetProof(dir string, g *game.Game) (Proof, bool, error) {
+	proofs, err := readProofs(dir, g)
+	if err != nil {
+		return Proof{}, false, err
+	}
+
+	p, ok := proofs[g.String()]
+
+	return p, ok, nil
+}
+
+func readProofs(dir string, g *game.Game) (map[string]Proof, error) {
+	proofs := map[string]Proof{}
+
+	data, err := os.ReadFile(proofsPath(dir, g))
+	if os.IsNotExist(err) {
+		return proofs, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proofs: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &proofs); err != nil {
+		return nil, fmt.Errorf("failed to parse proofs: %v", err)
+	}
+
+	return proofs, nil
+}
+
+func proofsPath(dir string, g *game.Game) string {
+	return filepath.Join(dir, g.GetMapKey()+".json")
+}
@@ -0,0 +1,234 @@
+package pns
+
+import (
+	"slices"
+	"tictactoe/internal/game"
+)
+
+type entry struct {
+	pn, dn int64
+	// work is the number of positions expanded below the entry, which
+	// decides which entries are dropped when the table is full.
+	work int64
+}
+
+// prover is a df-pn search for a forced win of the attacker. Positions
+// where the attacker moves are OR nodes, the others AND nodes.
+type prover struct {
+	solver   *Solver
+	attacker game.Player
+	table    map[uint64]entry
+	rootHash uint64
+}
+
+func newProver(s *Solver, attacker game.Player) *prover {
+	return &prover{
+		solver:   s,
+		attacker: attacker,
+		table:    map[uint64]entry{},
+		rootHash: s.root.Hash(),
+	}
+}
+
+func (p *prover) root() entry {
+	if e, ok := p.table[p.rootHash]; ok {
+		return e
+	}
+
+	return entry{pn: 1, dn: 1}
+}
+
+func (p *prover) decided() bool {
+	e := p.root()
+
+	return e.pn == 0 || e.dn == 0
+}
+
+func (p *prover) proved() bool {
+	return p.root().pn == 0
+}
+
+// mid searches below the position until its proof number reaches thpn or
+// its disproof number thdn, and returns its numbers. The numbers of the
+// children are kept while the position is searched, so entries dropped from
+// a full table do not make it lose track.
+func (p *prover) mid(g *game.Game, thpn, thdn int64) entry {
+	hash := g.Hash()
+	if e, ok := p.table[hash]; ok && (e.pn >= thpn || e.dn >= thdn) {
+		return e
+	}
+
+	if g.GetRules().IsTerminal(g) {
+		e := p.terminal(g)
+		p.store(hash, e)
+
+		return e
+	}
+
+	p.solver.nodes++
+
+	or := g.PlayerTurn == p.attacker
+	children := p.children(g)
+	numbers := make([]entry, len(children))
+	for k, c := range children {
+		numbers[k] = p.lookup(c)
+	}
+
+	var e entry
+
+	for {
+		e = combine(numbers, or)
+
+		if e.pn >= thpn || e.dn >= thdn || p.solver.exhausted() {
+			break
+		}
+
+		best, second := choose(numbers, or)
+		c := numbers[best]
+
+		var cthpn, cthdn int64
+		if or {
+			cthpn = min(thpn, second+1)
+			cthdn = add(thdn-e.dn, c.dn)
+		} else {
+			cthpn = add(thpn-e.pn, c.pn)
+			cthdn = min(thdn, second+1)
+		}
+
+		numbers[best] = p.mid(children[best], cthpn, cthdn)
+	}
+
+	p.store(hash, e)
+
+	return e
+}
+
+func (p *prover) children(g *game.Game) []*game.Game {
+	rules := g.GetRules()
+	moves := rules.LegalMoves(g)
+	res := make([]*game.Game, 0, len(moves))
+
+	for _, i := range moves {
+		c := g.Copy()
+		if err := rules.Apply(c, i); err != nil {
+			panic(err)
+		}
+
+		res = append(res, c)
+	}
+
+	return res
+}
+
+// combine takes the smallest proof number and the sum of the disproof
+// numbers of the children of an OR node, and the other way round for AND
+// nodes. The work is the one of all children and the node itself.
+func combine(children []entry, or bool) entry {
+	res := entry{pn: infinity, dn: 0, work: 1}
+	if !or {
+		res.pn, res.dn = 0, infinity
+	}
+
+	for _, c := range children {
+		if or {
+			res.pn = min(res.pn, c.pn)
+			res.dn = add(res.dn, c.dn)
+		} else {
+			res.pn = add(res.pn, c.pn)
+			res.dn = min(res.dn, c.dn)
+		}
+
+		res.work += c.work
+	}
+
+	return res
+}
+
+// choose returns the child with the smallest proof number of an OR node, or
+// disproof number of an AND node, and the second smallest number.
+func choose(children []entry, or bool) (int, int64) {
+	best, first, second := 0, int64(infinity), int64(infinity)
+
+	for k, c := range children {
+		n := c.dn
+		if or {
+			n = c.pn
+		}
+
+		switch {
+		case n < first:
+			best, first, second = k, n, first
+		case n < second:
+			second = n
+		}
+	}
+
+	return best, second
+}
+
+// lookup returns the numbers of a position from the table, deciding
+// terminal positions right away.
+func (p *prover) lookup(g *game.Game) entry {
+	hash := g.Hash()
+	if e, ok := p.table[hash]; ok {
+		return e
+	}
+
+	if g.GetRules().IsTerminal(g) {
+		e := p.terminal(g)
+		p.store(hash, e)
+
+		return e
+	}
+
+	return entry{pn: 1, dn: 1}
+}
+
+func (p *prover) terminal(g *game.Game) entry {
+	if g.GetRules().Outcome(g) == p.attacker {
+		return entry{pn: 0, dn: infinity}
+	}
+
+	return entry{pn: infinity, dn: 0}
+}
+
+// store keeps the entry, and when the table is full drops the entries with
+// the least work below them, keeping decided ones over undecided ones.
+func (p *prover) store(hash uint64, e entry) {
+	p.table[hash] = e
+
+	if len(p.table) <= p.solver.config.MaxEntries {
+		return
+	}
+
+	works := make([]int64, 0, len(p.table))
+	for _, e := range p.table {
+		works = append(works, weight(e))
+	}
+
+	slices.Sort(works)
+	limit := works[len(works)/2]
+
+	for h, e := range p.table {
+		if weight(e) <= limit && h != p.rootHash && h != hash {
+			delete(p.table, h)
+		}
+	}
+}
+
+// weight ranks the entries to keep, decided ones count as a lot of work.
+func weight(e entry) int64 {
+	if e.pn == 0 || e.dn == 0 {
+		return e.work + infinity
+	}
+
+	return e.work
+}
+
+func add(a, b int64) int64 {
+	if a >= infinity || b >= infinity {
+		return infinity
+	}
+
+	return min(a+b, infinity)
+}
@@ -0,0 +1,81 @@
+package pns
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"tictactoe/internal/game"
+)
+
+// state is the saved progress of a solver. Game strings do not carry the
+// win length, so it is kept next to the game.
+type state struct {
+	Game      string
+	WinLength int
+	Nodes     uint64
+	Entries   [2][]stateEntry
+}
+
+type stateEntry struct {
+	Hash         uint64
+	PN, DN, Work int64
+}
+
+// Save writes the tables of the solver to the file, so a long proof can be
+// resumed later.
+func (s *Solver) Save(path string) error {
+	st := state{Game: s.root.String(), WinLength: s.root.WinLength, Nodes: s.nodes}
+
+	for k, p := range s.provers {
+		for h, e := range p.table {
+			st.Entries[k] = append(st.Entries[k], stateEntry{Hash: h, PN: e.pn, DN: e.dn, Work: e.work})
+		}
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+
+	if err := gob.NewEncoder(file).Encode(st); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write state: %v", err)
+	}
+
+	return file.Close()
+}
+
+// Resume returns a solver continuing the proof saved in the file.
+func Resume(path string, c Config) (*Solver, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	var st state
+	if err := gob.NewDecoder(file).Decode(&st); err != nil {
+		return nil, fmt.Errorf("failed to read state: %v", err)
+	}
+
+	g, err := game.FromString(st.Game)
+	if err != nil {
+		return nil, err
+	}
+	g.WinLength = st.WinLength
+
+	s, err := New(g, c)
+	if err != nil {
+		return nil, err
+	}
+
+	s.nodes = st.Nodes
+
+	for k, p := range s.provers {
+		for _, e := range st.Entries[k] {
+			p.table[e.Hash] = entry{pn: e.PN, dn: e.DN, work: e.Work}
+		}
+	}
+
+	return s, nil
+}
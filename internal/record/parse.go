@@ -0,0 +1,254 @@
+package record
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"tictactoe/internal/game"
+	"unicode"
+)
+
+// Parse reads every record from the text. Records are separated by their tag
+// sections, a record without tags is a game on an empty 3x3 board.
+func Parse(str string) ([]*Record, error) {
+	p := &parser{src: []rune(str)}
+
+	if err := p.parse(); err != nil {
+		return nil, fmt.Errorf("line %d: %v", p.line(), err)
+	}
+
+	return p.records, nil
+}
+
+type parser struct {
+	src     []rune
+	pos     int
+	records []*Record
+	current *Record
+	lines   []*[]Move
+	start   *game.Game
+}
+
+func (p *parser) parse() error {
+	for {
+		p.skipSpace()
+
+		if p.pos >= len(p.src) {
+			break
+		}
+
+		switch c := p.src[p.pos]; c {
+		case '[':
+			if err := p.parseTag(); err != nil {
+				return err
+			}
+		case '{':
+			if err := p.parseComment(); err != nil {
+				return err
+			}
+		case ';':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+		case '(':
+			p.pos++
+			if err := p.openVariation(); err != nil {
+				return err
+			}
+		case ')':
+			p.pos++
+			if len(p.lines) < 2 {
+				return errors.New("unexpected end of variation")
+			}
+			if len(*p.lines[len(p.lines)-1]) == 0 {
+				return errors.New("empty variation")
+			}
+			p.lines = p.lines[:len(p.lines)-1]
+		default:
+			word := p.readWord()
+			if word == "" {
+				return fmt.Errorf("unexpected %q", c)
+			}
+
+			if err := p.parseWord(word); err != nil {
+				return err
+			}
+		}
+	}
+
+	if p.current != nil {
+		if len(p.lines) > 1 {
+			return errors.New("unterminated variation")
+		}
+		p.finish()
+	}
+
+	return nil
+}
+
+func (p *parser) record() *Record {
+	if p.current == nil {
+		p.current = &Record{}
+		p.lines = []*[]Move{&p.current.Moves}
+		p.start = nil
+	}
+
+	return p.current
+}
+
+func (p *parser) finish() {
+	p.records = append(p.records, p.current)
+	p.current = nil
+	p.lines = nil
+}
+
+func (p *parser) parseTag() error {
+	if p.current != nil && (len(p.current.Moves) > 0 || p.current.Comment != "") {
+		p.finish()
+	}
+
+	end := p.indexOf(']')
+	if end < 0 {
+		return errors.New("unterminated tag")
+	}
+
+	body := strings.TrimSpace(string(p.src[p.pos+1 : end]))
+	p.pos = end + 1
+
+	name, value, ok := strings.Cut(body, " ")
+	if !ok {
+		return fmt.Errorf("invalid tag %q", body)
+	}
+
+	unquoted, err := strconv.Unquote(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("invalid tag value %s: %v", value, err)
+	}
+
+	p.record().SetTag(name, unquoted)
+
+	return nil
+}
+
+func (p *parser) parseComment() error {
+	end := p.indexOf('}')
+	if end < 0 {
+		return errors.New("unterminated comment")
+	}
+
+	comment := strings.TrimSpace(string(p.src[p.pos+1 : end]))
+	p.pos = end + 1
+
+	r := p.record()
+	line := *p.lines[len(p.lines)-1]
+
+	switch {
+	case len(line) > 0:
+		line[len(line)-1].Comment = comment
+	case len(p.lines) == 1:
+		r.Comment = comment
+	default:
+		return errors.New("comment before the first move of a variation")
+	}
+
+	return nil
+}
+
+func (p *parser) openVariation() error {
+	p.record()
+
+	line := *p.lines[len(p.lines)-1]
+	if len(line) == 0 {
+		return errors.New("variation before the first move")
+	}
+
+	last := &line[len(line)-1]
+	last.Variations = append(last.Variations, nil)
+	p.lines = append(p.lines, &last.Variations[len(last.Variations)-1])
+
+	return nil
+}
+
+func (p *parser) parseWord(word string) error {
+	switch word {
+	case ResultXWon, ResultOWon, ResultDraw, ResultUnfinished:
+		r := p.record()
+		if len(p.lines) > 1 {
+			return errors.New("result inside a variation")
+		}
+
+		if r.Tag("Result") == "" {
+			r.SetTag("Result", word)
+		}
+
+		p.finish()
+		return nil
+	}
+
+	// Move numbers: "1.", "1...", or glued to the move as in "1.c3".
+	if i := strings.LastIndex(word, "."); i >= 0 {
+		if _, err := strconv.Atoi(strings.TrimRight(word[:i+1], ".")); err != nil {
+			return fmt.Errorf("invalid move number %q", word)
+		}
+
+		word = word[i+1:]
+		if word == "" {
+			return nil
+		}
+	}
+
+	r := p.record()
+
+	if p.start == nil {
+		start, err := r.Start()
+		if err != nil {
+			return err
+		}
+		p.start = start
+	}
+
+	cell, err := p.start.GetRules().ParseMove(p.start, word)
+	if err != nil {
+		return err
+	}
+
+	line := p.lines[len(p.lines)-1]
+	*line = append(*line, Move{Cell: cell})
+
+	return nil
+}
+
+func (p *parser) readWord() string {
+	start := p.pos
+
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if unicode.IsSpace(c) || strings.ContainsRune("[]{}();", c) {
+			break
+		}
+		p.pos++
+	}
+
+	return string(p.src[start:p.pos])
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) indexOf(c rune) int {
+	for i := p.pos; i < len(p.src); i++ {
+		if p.src[i] == c {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (p *parser) line() int {
+	return strings.Count(string(p.src[:min(p.pos, len(p.src))]), "\n") + 1
+}
@@ -0,0 +1,311 @@
+package record
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"tictactoe/internal/game"
+)
+
+const (
+	ResultXWon       = "1-0"
+	ResultOWon       = "0-1"
+	ResultDraw       = "1/2-1/2"
+	ResultUnfinished = "*"
+)
+
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// Move is a single move of the record. Variations are alternatives to this
+// move, every one of them starts at the same ply.
+type Move struct {
+	Cell       int
+	Comment    string
+	Variations [][]Move
+}
+
+// Record is a complete game with tag pairs followed by the move list, in the
+// spirit of chess PGN:
+//
+//	[X "Alice"]
+//	[O "Bob"]
+//	[Size "3"]
+//	[WinLength "3"]
+//	[Result "1-0"]
+//
+//	1. b2 {center} a1 (1... c3 2. a1) 2. c3 b1 3. c1 a2 4. c2 1-0
+type Record struct {
+	Tags    []Tag
+	Comment string
+	Moves   []Move
+}
+
+func (r *Record) Tag(name string) string {
+	for _, t := range r.Tags {
+		if t.Name == name {
+			return t.Value
+		}
+	}
+
+	return ""
+}
+
+func (r *Record) SetTag(name, value string) {
+	for i, t := range r.Tags {
+		if t.Name == name {
+			r.Tags[i].Value = value
+			return
+		}
+	}
+
+	r.Tags = append(r.Tags, Tag{Name: name, Value: value})
+}
+
+// Start returns the position the moves are made from: the Position tag if it
+// is present or an empty board of Size and WinLength otherwise.
+func (r *Record) Start() (*game.Game, error) {
+	var g *game.Game
+	var err error
+
+	if pos := r.Tag("Position"); pos != "" {
+		g, err = game.FromString(pos)
+	} else {
+		size := 3
+		if str := r.Tag("Size"); str != "" {
+			if size, err = strconv.Atoi(str); err != nil {
+				return nil, fmt.Errorf("invalid size tag: %v", err)
+			}
+		}
+
+		g, err = game.NewGame(size, game.DefaultWinLength(size))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if str := r.Tag("WinLength"); str != "" {
+		if g.WinLength, err = strconv.Atoi(str); err != nil {
+			return nil, fmt.Errorf("invalid win length tag: %v", err)
+		}
+
+		if err := g.ValidateWinLength(); err != nil {
+			return nil, err
+		}
+	}
+
+	if name := r.Tag("Rules"); name != "" {
+		rules, err := game.GetRuleset(name)
+		if err != nil {
+			return nil, err
+		}
+
+		rules.Setup(g)
+	}
+
+	if str := r.Tag("Scoring"); str != "" {
+		if g.Scoring, err = game.ParseScoringMode(str); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// Game replays the main line of the record.
+func (r *Record) Game() (*game.Game, error) {
+	start, err := r.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	// the cells are those of the start, so the moves are played by their
+	// names in case an expanding board grows
+	rules := start.GetRules()
+	moves := make([]string, len(r.Moves))
+	for i, m := range r.Moves {
+		moves[i] = rules.FormatMove(start, m.Cell)
+	}
+
+	g := start.Copy()
+	if err := g.PlayMoves(strings.Join(moves, " ")); err != nil {
+		return nil, err
+	}
+
+	if err := r.applyTermination(g); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// applyTermination ends the game the way the Termination tag tells when the
+// moves alone do not, such as on a resignation.
+func (r *Record) applyTermination(g *game.Game) error {
+	str := r.Tag("Termination")
+	if str == "" || g.IsOver() {
+		return nil
+	}
+
+	t, err := game.ParseTermination(str)
+	if err != nil {
+		return err
+	}
+
+	switch r.Tag("Result") {
+	case ResultXWon:
+		g.PlayerWon = game.PlayerX
+	case ResultOWon:
+		g.PlayerWon = game.PlayerO
+	}
+
+	g.Ended = t
+
+	return nil
+}
+
+// FromGame records the history of the game. Stones which are not in the
+// history are kept in the Position tag.
+func FromGame(g *game.Game, tags ...Tag) *Record {
+	r := &Record{}
+
+	for _, t := range tags {
+		r.SetTag(t.Name, t.Value)
+	}
+
+	r.SetTag("Size", strconv.Itoa(g.Size))
+	r.SetTag("WinLength", strconv.Itoa(g.WinLength))
+
+	r.SetTag("Rules", g.GetRules().Name())
+
+	if g.Scoring != game.ScoringNone {
+		r.SetTag("Scoring", g.Scoring.String())
+	}
+
+	r.SetTag("Result", Result(g))
+
+	if g.IsOver() {
+		r.SetTag("Termination", g.Termination().String())
+	}
+
+	// the copy keeps the shape of the board: its geometry and the origin of
+	// an expanding board, whose history is kept in the cells of its last size
+	start := g.Copy()
+	for _, i := range g.History {
+		start.Board[i] = game.PlayerNone
+	}
+
+	if len(g.History)%2 == 1 {
+		start.PlayerTurn = g.PlayerTurn.Opponent()
+	} else {
+		start.PlayerTurn = g.PlayerTurn
+	}
+
+	variant := g.VariantTokens()
+	board := g.BoardTokens()
+
+	if slices.ContainsFunc(start.Board, func(p game.Player) bool { return p != game.PlayerNone }) ||
+		start.PlayerTurn != game.PlayerX || len(variant) > 0 || len(board) > 0 {
+		tokens := []string{fmt.Sprintf("turn=%c", start.PlayerTurn)}
+		if len(variant) > 0 {
+			// the side to move follows from the turn order of the variant
+			tokens = variant
+		}
+
+		tokens = append(tokens, board...)
+		r.SetTag("Position", fmt.Sprintf("%c %s %s", game.PlayerNone, start.Board, strings.Join(tokens, " ")))
+	}
+
+	for _, i := range g.History {
+		r.Moves = append(r.Moves, Move{Cell: i})
+	}
+
+	return r
+}
+
+func Result(g *game.Game) string {
+	switch {
+	case g.PlayerWon == game.PlayerX:
+		return ResultXWon
+	case g.PlayerWon == game.PlayerO:
+		return ResultOWon
+	case g.IsOver():
+		return ResultDraw
+	default:
+		return ResultUnfinished
+	}
+}
+
+func (r *Record) String() string {
+	var sb strings.Builder
+
+	for _, t := range r.Tags {
+		sb.WriteString(fmt.Sprintf("[%s %s]\n", t.Name, strconv.Quote(t.Value)))
+	}
+
+	sb.WriteString("\n")
+
+	start, err := r.Start()
+	if err != nil {
+		start, _ = game.NewGame(3, 3)
+	}
+
+	var tokens []string
+
+	if r.Comment != "" {
+		tokens = append(tokens, "{"+r.Comment+"}")
+	}
+
+	tokens = append(tokens, formatMoves(start, r.Moves, 0)...)
+
+	result := r.Tag("Result")
+	if result == "" {
+		result = ResultUnfinished
+	}
+
+	tokens = append(tokens, result)
+	sb.WriteString(strings.Join(tokens, " "))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+func formatMoves(start *game.Game, moves []Move, ply int) []string {
+	var tokens []string
+
+	for i, m := range moves {
+		switch {
+		case (ply+i)%2 == 0:
+			tokens = append(tokens, fmt.Sprintf("%d.", (ply+i)/2+1))
+		case i == 0:
+			tokens = append(tokens, fmt.Sprintf("%d...", (ply+i)/2+1))
+		}
+
+		tokens = append(tokens, start.GetRules().FormatMove(start, m.Cell))
+
+		if m.Comment != "" {
+			tokens = append(tokens, "{"+m.Comment+"}")
+		}
+
+		for _, v := range m.Variations {
+			if len(v) == 0 {
+				continue
+			}
+
+			variation := formatMoves(start, v, ply+i)
+			variation[0] = "(" + variation[0]
+			variation[len(variation)-1] += ")"
+			tokens = append(tokens, variation...)
+		}
+
+		if len(m.Variations) > 0 && (ply+i)%2 == 0 && i+1 < len(moves) {
+			tokens = append(tokens, fmt.Sprintf("%d...", (ply+i)/2+1))
+		}
+	}
+
+	return tokens
+}
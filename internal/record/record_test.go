@@ -0,0 +1,169 @@
+package record
+
+import (
+	"strings"
+	"testing"
+	"tictactoe/internal/game"
+)
+
+const sample = `[Event "Casual"]
+[X "Alice"]
+[O "Bob"]
+[Size "3"]
+[WinLength "3"]
+[Result "1-0"]
+
+{An old game} 1. b2 {center} a1 (1... c3 2. a1) 2. c3 b1 3. c1 a2 4. c2 1-0
+
+[Size "5"]
+[WinLength "4"]
+
+1. c3 d4 *
+`
+
+func TestParse(t *testing.T) {
+	records, err := Parse(sample)
+	if err != nil {
+		t.Fatalf("Failed to parse records: %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	r := records[0]
+	if r.Tag("X") != "Alice" || r.Tag("Result") != ResultXWon || r.Comment != "An old game" {
+		t.Fatalf("Unexpected tags %v and comment %q", r.Tags, r.Comment)
+	}
+
+	if len(r.Moves) != 7 || r.Moves[0].Comment != "center" || len(r.Moves[1].Variations) != 1 {
+		t.Fatalf("Unexpected moves %v", r.Moves)
+	}
+
+	if v := r.Moves[1].Variations[0]; len(v) != 2 || v[0].Cell != 2 || v[1].Cell != 6 {
+		t.Fatalf("Unexpected variation %v", v)
+	}
+
+	g, err := r.Game()
+	if err != nil {
+		t.Fatalf("Failed to replay the record: %v", err)
+	}
+
+	if g.PlayerWon != game.PlayerX {
+		t.Fatalf("Expected PlayerX to win, got %c", g.PlayerWon)
+	}
+
+	if records[1].Tag("Result") != ResultUnfinished || records[1].Moves[1].Cell != 8 {
+		t.Fatalf("Unexpected second record %v", records[1])
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	records, _ := Parse(sample)
+
+	str := records[0].String()
+	if !strings.Contains(str, "1. b2 {center} a1 (1... c3 2. a1) 2. c3 b1 3. c1 a2 4. c2 1-0") {
+		t.Fatalf("Unexpected record\n%s", str)
+	}
+
+	again, err := Parse(str)
+	if err != nil || len(again) != 1 || again[0].String() != str {
+		t.Fatalf("Expected the record to round trip, got %v", err)
+	}
+}
+
+func TestFromGame(t *testing.T) {
+	g, _ := game.FromString("_ ____X____")
+	g.MakeMoveByIndex(0)
+	g.MakeMoveByIndex(8)
+
+	r := FromGame(g, Tag{Name: "X", Value: "Alice"})
+
+	replayed, err := r.Game()
+	if err != nil {
+		t.Fatalf("Failed to replay the record: %v", err)
+	}
+
+	if replayed.String() != g.String() || replayed.PlayerTurn != g.PlayerTurn {
+		t.Fatalf("Expected %s, got %s", g, replayed)
+	}
+
+	if r.Tag("Position") != "_ ____X____ turn=O" {
+		t.Fatalf("Unexpected position tag %q", r.Tag("Position"))
+	}
+}
+
+func TestTermination(t *testing.T) {
+	g, _ := game.NewGame(3, 3)
+	g.MakeMoveByIndex(4)
+	g.Resign(game.PlayerO)
+
+	r := FromGame(g)
+	if r.Tag("Result") != ResultXWon || r.Tag("Termination") != "resignation" {
+		t.Fatalf("Unexpected tags %v", r.Tags)
+	}
+
+	replayed, err := r.Game()
+	if err != nil {
+		t.Fatalf("Failed to replay the record: %v", err)
+	}
+
+	if replayed.PlayerWon != game.PlayerX || replayed.Termination() != game.TerminationResignation {
+		t.Fatalf("Expected X to win by resignation, got %s", replayed)
+	}
+}
+
+func TestStartSize(t *testing.T) {
+	r := &Record{}
+	r.SetTag("Size", "100000")
+
+	if _, err := r.Start(); err == nil {
+		t.Fatalf("Expected an error for a board larger than the largest map")
+	}
+}
+
+func TestEmptyVariation(t *testing.T) {
+	if _, err := Parse("1. b2 () a1 *"); err == nil {
+		t.Fatalf("Expected an error for an empty variation")
+	}
+
+	r := &Record{Moves: []Move{{Cell: 4, Variations: [][]Move{nil}}}}
+	if str := r.String(); !strings.Contains(str, "1. b2") {
+		t.Fatalf("Expected the move without the empty variation, got %q", str)
+	}
+}
+
+func TestFromGameShapes(t *testing.T) {
+	hex, _ := game.NewHexGame(2, 3)
+	if err := hex.PlayMoves("0,0 1,-1 -1,1"); err != nil {
+		t.Fatalf("Failed to play moves: %v", err)
+	}
+
+	expanding, _ := game.NewGame(3, 3)
+	expanding.ExpandMargin = 1
+	if err := expanding.PlayMoves("1,1 0,1 2,2"); err != nil {
+		t.Fatalf("Failed to play moves: %v", err)
+	}
+
+	for _, g := range []*game.Game{hex, expanding} {
+		str := FromGame(g).String()
+
+		records, err := Parse(str)
+		if err != nil || len(records) != 1 {
+			t.Fatalf("Failed to parse the record\n%s: %v", str, err)
+		}
+
+		if records[0].String() != str {
+			t.Fatalf("Expected the record to round trip\n%s, got\n%s", str, records[0])
+		}
+
+		replayed, err := records[0].Game()
+		if err != nil {
+			t.Fatalf("Failed to replay the record\n%s: %v", str, err)
+		}
+
+		if replayed.String() != g.String() {
+			t.Fatalf("Expected %s, got %s", g, replayed)
+		}
+	}
+}
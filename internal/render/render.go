@@ -0,0 +1,244 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"math"
+	"slices"
+	"strings"
+	"tictactoe/internal/game"
+)
+
+var (
+	colorBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	colorCell       = color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+	colorLastMove   = color.RGBA{R: 0xff, G: 0xe9, B: 0xa8, A: 0xff}
+	colorMark       = color.RGBA{R: 0x11, G: 0x11, B: 0x11, A: 0xff}
+	colorWon        = color.RGBA{R: 0xee, G: 0x55, B: 0x55, A: 0xff}
+	colorBlocked    = color.RGBA{R: 0x88, G: 0x88, B: 0x88, A: 0xff}
+
+	palette = color.Palette{colorBackground, colorCell, colorLastMove, colorMark, colorWon, colorBlocked}
+)
+
+const (
+	MinCellSize = 10
+	MaxCellSize = 200
+	// MaxPixels bounds the pixels of all frames of an image together.
+	MaxPixels = 64 << 20
+)
+
+type Options struct {
+	CellSize int
+}
+
+func DefaultOptions() Options {
+	return Options{CellSize: 60}
+}
+
+// cellLayout returns the gap between cells and the total image size, the
+// same proportions the web component uses.
+func (o Options) cellLayout(size int) (int, int) {
+	gap := max(1, o.CellSize/10)
+	return gap, size*o.CellSize + (size+1)*gap
+}
+
+// Fit shrinks the cells down to MinCellSize until the given number of frames
+// of a board of the size stay within MaxPixels together.
+func (o Options) Fit(size, frames int) (Options, error) {
+	for {
+		_, total := o.cellLayout(size)
+		if total*total*frames <= MaxPixels {
+			return o, nil
+		}
+
+		if o.CellSize <= MinCellSize {
+			return o, fmt.Errorf("%d frames of a board of size %d are too large to render", frames, size)
+		}
+
+		o.CellSize = max(MinCellSize, o.CellSize*3/4)
+	}
+}
+
+func (o Options) cellOrigin(g *game.Game, i int) (int, int) {
+	gap, _ := o.cellLayout(g.Size)
+	x, y := i%g.Size, i/g.Size
+	return gap + x*(o.CellSize+gap), gap + y*(o.CellSize+gap)
+}
+
+func lastMove(g *game.Game) int {
+	if len(g.History) == 0 {
+		return -1
+	}
+
+	return g.History[len(g.History)-1]
+}
+
+// SVG renders the board with the winning line and the last move highlighted.
+func SVG(g *game.Game, opts Options) string {
+	_, total := opts.cellLayout(g.Size)
+	winLine := g.GetWinLine()
+	last := lastMove(g)
+	c := opts.CellSize
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`, total, total, total, total))
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="%d" fill="%s"/>`, total, total, hex(colorBackground)))
+
+	for i, p := range g.Board {
+		x, y := opts.cellOrigin(g, i)
+
+		fill := colorCell
+		if i == last {
+			fill = colorLastMove
+		} else if p == game.PlayerBlocked {
+			fill = colorBlocked
+		}
+
+		sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`, x, y, c, c, hex(fill)))
+
+		stroke := colorMark
+		if slices.Contains(winLine, i) {
+			stroke = colorWon
+		}
+
+		width := float64(c) * 0.08
+		pad := float64(c) * 0.2
+
+		switch p {
+		case game.PlayerX:
+			sb.WriteString(fmt.Sprintf(
+				`<path d="M%.1f %.1fL%.1f %.1fM%.1f %.1fL%.1f %.1f" stroke="%s" stroke-width="%.1f" stroke-linecap="round"/>`,
+				float64(x)+pad, float64(y)+pad, float64(x+c)-pad, float64(y+c)-pad,
+				float64(x+c)-pad, float64(y)+pad, float64(x)+pad, float64(y+c)-pad,
+				hex(stroke), width,
+			))
+		case game.PlayerO:
+			sb.WriteString(fmt.Sprintf(
+				`<circle cx="%.1f" cy="%.1f" r="%.1f" fill="none" stroke="%s" stroke-width="%.1f"/>`,
+				float64(x)+float64(c)/2, float64(y)+float64(c)/2, float64(c)/2-pad, hex(stroke), width,
+			))
+		}
+	}
+
+	sb.WriteString(`</svg>`)
+
+	return sb.String()
+}
+
+// Image renders the board the same way SVG does into a raster image.
+func Image(g *game.Game, opts Options) *image.RGBA {
+	_, total := opts.cellLayout(g.Size)
+	img := image.NewRGBA(image.Rect(0, 0, total, total))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colorBackground), image.Point{}, draw.Src)
+
+	winLine := g.GetWinLine()
+	last := lastMove(g)
+	c := opts.CellSize
+
+	for i, p := range g.Board {
+		x, y := opts.cellOrigin(g, i)
+		rect := image.Rect(x, y, x+c, y+c)
+
+		fill := colorCell
+		if i == last {
+			fill = colorLastMove
+		} else if p == game.PlayerBlocked {
+			fill = colorBlocked
+		}
+
+		draw.Draw(img, rect, image.NewUniform(fill), image.Point{}, draw.Src)
+
+		stroke := colorMark
+		if slices.Contains(winLine, i) {
+			stroke = colorWon
+		}
+
+		switch p {
+		case game.PlayerX:
+			drawCross(img, rect, stroke)
+		case game.PlayerO:
+			drawCircle(img, rect, stroke)
+		}
+	}
+
+	return img
+}
+
+// GIF renders the positions one frame each, the last one held longer. The
+// cells are shrunk to fit the frames within MaxPixels.
+func GIF(positions []*game.Game, opts Options) (*gif.GIF, error) {
+	if len(positions) == 0 {
+		return nil, errors.New("no positions to render")
+	}
+
+	size := 0
+	for _, g := range positions {
+		size = max(size, g.Size)
+	}
+
+	opts, err := opts.Fit(size, len(positions))
+	if err != nil {
+		return nil, err
+	}
+
+	// an expanding board grows during the game, every frame is as large as
+	// the largest board
+	_, total := opts.cellLayout(size)
+	anim := &gif.GIF{}
+
+	for _, g := range positions {
+		frame := image.NewPaletted(image.Rect(0, 0, total, total), palette)
+		draw.Draw(frame, frame.Bounds(), image.NewUniform(colorBackground), image.Point{}, draw.Src)
+		draw.Draw(frame, frame.Bounds(), Image(g, opts), image.Point{}, draw.Src)
+
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, 80)
+	}
+
+	anim.Delay[len(anim.Delay)-1] = 300
+
+	return anim, nil
+}
+
+func drawCross(img *image.RGBA, rect image.Rectangle, c color.Color) {
+	size := float64(rect.Dx())
+	pad, width := size*0.2, size*0.08
+
+	for py := rect.Min.Y; py < rect.Max.Y; py++ {
+		for px := rect.Min.X; px < rect.Max.X; px++ {
+			x, y := float64(px-rect.Min.X)+0.5, float64(py-rect.Min.Y)+0.5
+
+			if x < pad-width/2 || y < pad-width/2 || x > size-pad+width/2 || y > size-pad+width/2 {
+				continue
+			}
+
+			if math.Abs(x-y)/math.Sqrt2 <= width/2 || math.Abs(x+y-size)/math.Sqrt2 <= width/2 {
+				img.Set(px, py, c)
+			}
+		}
+	}
+}
+
+func drawCircle(img *image.RGBA, rect image.Rectangle, c color.Color) {
+	size := float64(rect.Dx())
+	radius, width := size/2-size*0.2, size*0.08
+
+	for py := rect.Min.Y; py < rect.Max.Y; py++ {
+		for px := rect.Min.X; px < rect.Max.X; px++ {
+			x, y := float64(px-rect.Min.X)+0.5-size/2, float64(py-rect.Min.Y)+0.5-size/2
+
+			if math.Abs(math.Hypot(x, y)-radius) <= width/2 {
+				img.Set(px, py, c)
+			}
+		}
+	}
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"tictactoe/internal/game"
+)
+
+func TestSVG(t *testing.T) {
+	g, _ := game.NewGame(3, 3)
+	for _, i := range []int{0, 1, 3, 4, 6} {
+		g.MakeMoveByIndex(i)
+	}
+
+	svg := SVG(g, DefaultOptions())
+
+	if !strings.HasPrefix(svg, "<svg") || !strings.HasSuffix(svg, "</svg>") {
+		t.Fatalf("Expected an svg document, got %s", svg)
+	}
+
+	if strings.Count(svg, `stroke="#ee5555"`) != 3 {
+		t.Fatalf("Expected the three cells of the winning line to be highlighted")
+	}
+
+	if strings.Count(svg, `fill="#ffe9a8"`) != 1 {
+		t.Fatalf("Expected the last move to be highlighted")
+	}
+}
+
+func TestImage(t *testing.T) {
+	g, _ := game.FromString("_ X___O____")
+	opts := DefaultOptions()
+	img := Image(g, opts)
+
+	gap, total := opts.cellLayout(3)
+	if img.Bounds().Dx() != total || img.Bounds().Dy() != total {
+		t.Fatalf("Expected image of %dx%d, got %v", total, total, img.Bounds())
+	}
+
+	center := gap + opts.CellSize/2
+	if img.RGBAAt(center, center) != colorMark {
+		t.Fatalf("Expected the cross in the center of the first cell, got %v", img.RGBAAt(center, center))
+	}
+}
+
+func TestGIF(t *testing.T) {
+	g, _ := game.NewGame(3, 3)
+	positions := []*game.Game{g.Copy()}
+
+	for _, i := range []int{4, 0, 8} {
+		g.MakeMoveByIndex(i)
+		positions = append(positions, g.Copy())
+	}
+
+	anim, err := GIF(positions, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Failed to render gif: %v", err)
+	}
+
+	if len(anim.Image) != 4 {
+		t.Fatalf("Expected 4 frames, got %d", len(anim.Image))
+	}
+
+	if _, err := GIF(nil, DefaultOptions()); err == nil {
+		t.Fatalf("Expected an error without positions")
+	}
+}
+
+func TestFit(t *testing.T) {
+	opts := Options{CellSize: 200}
+
+	fitted, err := opts.Fit(game.MaxSize, game.MaxSize*game.MaxSize+1)
+	if err != nil {
+		t.Fatalf("Failed to fit the largest board: %v", err)
+	}
+
+	if _, total := fitted.cellLayout(game.MaxSize); total*total*(game.MaxSize*game.MaxSize+1) > MaxPixels {
+		t.Fatalf("Expected the frames to fit in %d pixels with cells of %d", MaxPixels, fitted.CellSize)
+	}
+
+	if fitted, _ := opts.Fit(3, 1); fitted.CellSize != 200 {
+		t.Fatalf("Expected a small board to keep its cells, got %d", fitted.CellSize)
+	}
+
+	if _, err := opts.Fit(1000, 1000); err == nil {
+		t.Fatalf("Expected an error for frames which do not fit")
+	}
+}
+
+func TestGIFBlocked(t *testing.T) {
+	g, _ := game.FromString("_ ____#____")
+	opts := DefaultOptions()
+
+	anim, err := GIF([]*game.Game{g}, opts)
+	if err != nil {
+		t.Fatalf("Failed to render gif: %v", err)
+	}
+
+	x, y := opts.cellOrigin(g, 4)
+	if c := anim.Image[0].At(x+1, y+1); c != colorBlocked {
+		t.Fatalf("Expected the blocked cell in %v, got %v", colorBlocked, c)
+	}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tictactoe/internal/game"
+	"tictactoe/internal/tss"
+)
+
+// analysis adds the forced wins of threat sequences to the analysis of the
+// board, on boards where they decide the game.
+type analysis struct {
+	game.Analysis
+	Forced *forcedWins `json:"forced,omitempty"`
+}
+
+// forcedWins are the forced wins of both players as if it was their turn.
+type forcedWins struct {
+	X tss.Result `json:"x"`
+	O tss.Result `json:"o"`
+}
+
+func (s *Server) registerAnalysisRoutes() {
+	s.r.GET("/api/analysis", func(c *gin.Context) {
+		g, err := s.parseGame(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		res := analysis{Analysis: g.Analyze()}
+
+		if tss.Supported(g) && !g.IsOver() {
+			res.Forced = &forcedWins{X: forcedWin(g, game.PlayerX), O: forcedWin(g, game.PlayerO)}
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   res,
+		})
+	})
+}
+
+// forcedWin returns the shortest win of the player by continuous fours, or
+// else by continuous threats.
+func forcedWin(g *game.Game, p game.Player) tss.Result {
+	var res tss.Result
+
+	for _, kind := range []tss.Kind{tss.KindVCF, tss.KindVCT} {
+		if res, _ = tss.Search(g, p, kind, tss.DefaultDepth); res.Win {
+			break
+		}
+	}
+
+	return res
+}
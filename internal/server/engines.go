@@ -0,0 +1,61 @@
+package server
+
+import (
+	"slices"
+	"sync"
+	"tictactoe/internal/game"
+	"tictactoe/internal/mcts"
+)
+
+// mctsMinSize is the board size from which maps can not be built and next
+// moves are searched with MCTS.
+const mctsMinSize = 7
+
+// maxIdleEngines bounds the trees kept between the requests of games.
+const maxIdleEngines = 16
+
+// engines keeps the MCTS engines of recent games, so the tree of a game is
+// reused by the requests of its next moves. An engine searches for one
+// request at a time, concurrent requests get engines of their own.
+type engines struct {
+	mu sync.Mutex
+	// idle engines, the least recently used first
+	idle []*mcts.Engine
+}
+
+func newEngines() *engines {
+	return &engines{}
+}
+
+// search returns the best move of the game with the engine whose tree
+// continues it, or with a new engine.
+func (e *engines) search(g *game.Game) (mcts.Result, error) {
+	engine := e.acquire(g)
+	defer e.release(engine)
+
+	return engine.Search(g)
+}
+
+func (e *engines) acquire(g *game.Game) *mcts.Engine {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i := len(e.idle) - 1; i >= 0; i-- {
+		if engine := e.idle[i]; engine.Continues(g) {
+			e.idle = slices.Delete(e.idle, i, i+1)
+			return engine
+		}
+	}
+
+	return mcts.New(mcts.DefaultConfig())
+}
+
+func (e *engines) release(engine *mcts.Engine) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.idle = append(e.idle, engine)
+	if len(e.idle) > maxIdleEngines {
+		e.idle = slices.Delete(e.idle, 0, len(e.idle)-maxIdleEngines)
+	}
+}
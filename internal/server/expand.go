@@ -0,0 +1,43 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tictactoe/internal/game"
+	"tictactoe/internal/heuristic"
+)
+
+// nextExpandingMove answers /api/next-move on an expanding board. The board
+// first catches up with the growth the client's last stone caused, then the
+// engine's move is made. When the board has grown, "resize" tells the client
+// the new size and where the cells of the requested board are now, and "x"
+// and "y" are given on the new board.
+func nextExpandingMove(c *gin.Context, g *game.Game) {
+	size, originX, originY := g.Size, g.OriginX, g.OriginY
+
+	g.Grow()
+
+	i, err := heuristic.BestMove(g)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	move := g.FormatMove(i)
+	if err := g.GetRules().Apply(g, i); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	i, _ = g.ParseMove(move)
+
+	data := gin.H{"x": i % g.Size, "y": i / g.Size, "move": move, "game": g.String()}
+	if g.Size != size {
+		data["resize"] = gin.H{"size": g.Size, "offsetX": originX - g.OriginX, "offsetY": originY - g.OriginY}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ok",
+		"data":   data,
+	})
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"tictactoe/internal/fog"
+	"tictactoe/internal/game"
+)
+
+func (s *Server) registerFogRoutes() {
+	s.r.POST("/api/fog/new", func(c *gin.Context) {
+		size, err := strconv.Atoi(c.DefaultQuery("size", "3"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid size: " + err.Error()})
+			return
+		}
+
+		win, err := strconv.Atoi(c.DefaultQuery("win", strconv.Itoa(game.DefaultWinLength(size))))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid win length: " + err.Error()})
+			return
+		}
+
+		session, err := fog.NewSession(fog.Config{Size: size, WinLength: win, CostsTurn: c.Query("costsTurn") == "1"})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		s.fog.Add(session)
+
+		// each player gets only their own token, the creator hands them out
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data": gin.H{
+				"id":     session.ID,
+				"tokens": gin.H{"X": session.Token(game.PlayerX), "O": session.Token(game.PlayerO)},
+				"view":   session.View(game.PlayerX),
+			},
+		})
+	})
+
+	s.r.GET("/api/fog/view", func(c *gin.Context) {
+		session, p, err := s.parseFogSession(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   session.View(p),
+		})
+	})
+
+	s.r.POST("/api/fog/move", func(c *gin.Context) {
+		session, p, err := s.parseFogSession(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		move, err := session.Game.ParseMove(c.Query("move"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		attempt, err := session.Try(p, move)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   gin.H{"attempt": attempt, "view": session.View(p)},
+		})
+	})
+
+	s.r.GET("/api/fog/engine", func(c *gin.Context) {
+		session, p, err := s.parseFogSession(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		samples, err := strconv.Atoi(c.DefaultQuery("samples", strconv.Itoa(fog.DefaultSamples)))
+		if err != nil || samples < 1 || samples > fog.MaxSamples {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("samples must be between 1 and %d", fog.MaxSamples)})
+			return
+		}
+
+		v := session.View(p)
+
+		i, err := fog.BestMove(v, samples, rand.New(rand.NewSource(rand.Int63())))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		x, y := i%v.Size, i/v.Size
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   gin.H{"x": x, "y": y, "move": game.FormatCoordinates(x, y, v.Size)},
+		})
+	})
+}
+
+// parseFogSession returns the session "id" and the player its secret
+// "token" belongs to.
+func (s *Server) parseFogSession(c *gin.Context) (*fog.Session, game.Player, error) {
+	session, err := s.fog.Get(c.Query("id"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	p, err := session.Player(c.Query("token"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return session, p, nil
+}
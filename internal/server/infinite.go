@@ -0,0 +1,79 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"tictactoe/internal/game"
+)
+
+func (s *Server) registerInfiniteRoutes() {
+	s.r.GET("/api/infinite/state", func(c *gin.Context) {
+		g, err := game.InfiniteFromString(c.Query("game"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   infiniteState(g),
+		})
+	})
+
+	s.r.GET("/api/infinite/move", func(c *gin.Context) {
+		g, err := game.InfiniteFromString(c.Query("game"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		x, err := strconv.Atoi(c.Query("x"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid x: " + err.Error()})
+			return
+		}
+
+		y, err := strconv.Atoi(c.Query("y"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid y: " + err.Error()})
+			return
+		}
+
+		if err := g.MakeMove(x, y); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   infiniteState(g),
+		})
+	})
+}
+
+func infiniteState(g *game.InfiniteGame) gin.H {
+	minP, maxP := g.Bounds()
+
+	stones := make([]gin.H, 0, len(g.Moves))
+	for _, p := range g.Moves {
+		stones = append(stones, gin.H{"x": p.X, "y": p.Y, "player": string(g.Stones[p])})
+	}
+
+	winLine := make([]gin.H, 0, len(g.WinLine))
+	for _, p := range g.WinLine {
+		winLine = append(winLine, gin.H{"x": p.X, "y": p.Y})
+	}
+
+	return gin.H{
+		"game":    g.String(),
+		"turn":    string(g.PlayerTurn),
+		"won":     string(g.PlayerWon),
+		"stones":  stones,
+		"winLine": winLine,
+		"bounds": gin.H{
+			"minX": minP.X, "minY": minP.Y,
+			"maxX": maxP.X, "maxY": maxP.Y,
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tictactoe/internal/game"
+	"tictactoe/internal/heuristic"
+)
+
+func (s *Server) registerOpeningRoutes() {
+	s.r.GET("/api/opening/state", func(c *gin.Context) {
+		g, err := s.parseGame(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   openingState(g),
+		})
+	})
+
+	s.r.GET("/api/opening/place", func(c *gin.Context) {
+		g, err := s.parseGame(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if phase, _ := g.OpeningPhase(); phase != game.PhasePlace {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "opening stones can not be placed now"})
+			return
+		}
+
+		move, err := g.ParseMove(c.Query("move"))
+		if err == nil {
+			err = g.GetRules().Apply(g, move)
+		}
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   openingState(g),
+		})
+	})
+
+	s.r.GET("/api/opening/swap", func(c *gin.Context) {
+		g, err := s.parseGame(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := g.ChooseOpening(game.OpeningChoice(c.DefaultQuery("choice", string(game.ChoiceSwap)))); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   openingState(g),
+		})
+	})
+
+	s.r.GET("/api/opening/engine", func(c *gin.Context) {
+		g, err := s.parseGame(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		switch phase, _ := g.OpeningPhase(); phase {
+		case game.PhasePlace:
+			i, err := heuristic.BalancedMove(g)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+
+			x, y := i%g.Size, i/g.Size
+			c.JSON(http.StatusOK, gin.H{
+				"status": "ok",
+				"data":   gin.H{"x": x, "y": y, "move": g.FormatMove(x + y*g.Size)},
+			})
+		case game.PhaseChoose:
+			choice, err := heuristic.OpeningChoice(g)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{
+				"status": "ok",
+				"data":   gin.H{"choice": choice},
+			})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "the opening is over, use /api/next-move"})
+		}
+	})
+}
+
+func openingState(g *game.Game) gin.H {
+	phase, seat := g.OpeningPhase()
+
+	return gin.H{
+		"game":    g.String(),
+		"opening": g.Opening.String(),
+		"phase":   phase,
+		"seat":    seat,
+		"options": g.OpeningOptions(),
+		"colors": gin.H{
+			"first":  string(g.ColorOf(game.SeatFirst)),
+			"second": string(g.ColorOf(game.SeatSecond)),
+		},
+		"turn": string(g.PlayerTurn),
+	}
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strconv"
+	"tictactoe/internal/game"
+)
+
+// parseGame reads the position from the "game" query, or from the "start"
+// position (an empty board of "size" by default) followed by the algebraic
+// move list "moves". Under an opening protocol the choices "choices" are
+// taken whenever the protocol asks for one.
+func (s *Server) parseGame(c *gin.Context) (*game.Game, error) {
+	g, err := s.parseStart(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := playMoves(c, g); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// playMoves makes the moves "moves" and the opening choices "choices" on the
+// start position.
+func playMoves(c *gin.Context, g *game.Game) error {
+	if g.ExpandMargin > 0 {
+		return g.PlayMoves(c.Query("moves"))
+	}
+
+	moves, err := parseMoves(c, g)
+	if err != nil {
+		return err
+	}
+
+	if g.Opening != game.OpeningNone || c.Query("choices") != "" {
+		choices, err := game.ParseOpeningChoices(c.Query("choices"))
+		if err != nil {
+			return err
+		}
+
+		return g.ApplyOpening(moves, choices)
+	}
+
+	return g.ApplyMoves(moves)
+}
+
+func parseMoves(c *gin.Context, g *game.Game) ([]int, error) {
+	if moves := c.Query("moves"); moves != "" {
+		return g.ParseMoves(moves)
+	}
+
+	return nil, nil
+}
+
+// parseStart reads the position the moves are made from and applies the
+// optional variant "variant", win length "win", rules "rules", scoring mode
+// "scoring", expand margin "expand", opening protocol "opening" and custom
+// win shapes given with "shape", "rotate" and "reflect".
+func (s *Server) parseStart(c *gin.Context) (*game.Game, error) {
+	g, err := s.parsePosition(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if win := c.Query("win"); win != "" {
+		if g.WinLength, err = strconv.Atoi(win); err != nil {
+			return nil, fmt.Errorf("invalid win length: %v", err)
+		}
+	}
+
+	if name := c.Query("rules"); name != "" {
+		rules, err := game.GetRuleset(name)
+		if err != nil {
+			return nil, err
+		}
+
+		rules.Setup(g)
+	}
+
+	if scoring := c.Query("scoring"); scoring != "" {
+		if g.Scoring, err = game.ParseScoringMode(scoring); err != nil {
+			return nil, err
+		}
+	}
+
+	if expand := c.Query("expand"); expand != "" {
+		if g.ExpandMargin, err = strconv.Atoi(expand); err != nil {
+			return nil, fmt.Errorf("invalid expand margin: %v", err)
+		}
+	}
+
+	if opening := c.Query("opening"); opening != "" {
+		if g.Opening, err = game.ParseOpeningProtocol(opening); err != nil {
+			return nil, err
+		}
+	}
+
+	if shapes := c.QueryArray("shape"); len(shapes) > 0 {
+		var parsed []game.Shape
+
+		for _, str := range shapes {
+			sh, err := game.ParseShape(str)
+			if err != nil {
+				return nil, err
+			}
+
+			parsed = append(parsed, sh)
+		}
+
+		g.SetWinShapes(parsed, c.Query("rotate") == "1", c.Query("reflect") == "1")
+	}
+
+	if err := g.ValidateWinLength(); err != nil {
+		return nil, err
+	}
+
+	if c.Query("adjudicate") == "1" {
+		g.Adjudicate = true
+	}
+
+	if g.Scoring != game.ScoringNone || g.Adjudicate {
+		g.CheckWin()
+	}
+
+	return g, nil
+}
+
+func (s *Server) parsePosition(c *gin.Context) (*game.Game, error) {
+	if id := c.Query("variant"); id != "" {
+		return s.parseVariantPosition(c, id)
+	}
+
+	if str := c.Query("game"); str != "" {
+		return game.FromString(str)
+	}
+
+	if str := c.Query("start"); str != "" {
+		return game.FromString(str)
+	}
+
+	if c.Query("geometry") == game.GeometryHex.String() {
+		radius, err := strconv.Atoi(c.DefaultQuery("radius", "3"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid radius: %v", err)
+		}
+
+		return game.NewHexGame(radius, game.DefaultHexWinLength(radius))
+	}
+
+	size, err := strconv.Atoi(c.DefaultQuery("size", "3"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid size: %v", err)
+	}
+
+	return game.NewGame(size, game.DefaultWinLength(size))
+}
+
+// parseVariantPosition returns the empty board of the variant, or the given
+// "game" or "start" position played under its rules.
+func (s *Server) parseVariantPosition(c *gin.Context, id string) (*game.Game, error) {
+	v, ok := s.variants[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown variant %q", id)
+	}
+
+	str := c.Query("game")
+	if str == "" {
+		str = c.Query("start")
+	}
+
+	if str == "" {
+		return v.NewGame()
+	}
+
+	g, err := game.FromString(str)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.Apply(g); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tictactoe/internal/pns"
+)
+
+// proofsDir is the directory of the proofs written by cmd/pns.
+const proofsDir = "./proofs"
+
+func (s *Server) registerProofRoutes() {
+	s.r.GET("/api/proof", func(c *gin.Context) {
+		g, err := s.parseGame(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		p, ok, err := pns.GetProof(proofsDir, g)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "the position is not proved"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   p,
+		})
+	})
+}
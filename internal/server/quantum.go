@@ -0,0 +1,98 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"tictactoe/internal/game"
+)
+
+func (s *Server) registerQuantumRoutes() {
+	s.r.GET("/api/quantum/state", func(c *gin.Context) {
+		g, err := game.QuantumFromString(c.Query("game"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   quantumState(g),
+		})
+	})
+
+	s.r.GET("/api/quantum/move", func(c *gin.Context) {
+		g, err := game.QuantumFromString(c.Query("game"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var cells []int
+		for _, str := range strings.Split(c.Query("cells"), ",") {
+			cell, err := strconv.Atoi(str)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cells: " + err.Error()})
+				return
+			}
+
+			cells = append(cells, cell)
+		}
+
+		if len(cells) == 1 {
+			cells = append(cells, cells[0])
+		}
+
+		if len(cells) != 2 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "a move takes one or two cells"})
+			return
+		}
+
+		if err := g.MakeSpookyMove(cells[0], cells[1]); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   quantumState(g),
+		})
+	})
+
+	s.r.GET("/api/quantum/collapse", func(c *gin.Context) {
+		g, err := game.QuantumFromString(c.Query("game"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cell, err := strconv.Atoi(c.Query("cell"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cell: " + err.Error()})
+			return
+		}
+
+		if err := g.Collapse(cell); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   quantumState(g),
+		})
+	})
+}
+
+func quantumState(g *game.QuantumGame) gin.H {
+	return gin.H{
+		"game":            g.String(),
+		"cells":           g.Cells(),
+		"turn":            string(g.PlayerTurn),
+		"won":             string(g.PlayerWon),
+		"score":           gin.H{"x": g.ScoreX, "o": g.ScoreO},
+		"collapseOptions": g.CollapseOptions(),
+		"over":            g.IsOver(),
+	}
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"sort"
+	"tictactoe/internal/record"
+)
+
+func (s *Server) registerRecordRoutes() {
+	s.r.POST("/api/records/import", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		records, err := record.Parse(string(body))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		games := make([]gin.H, 0, len(records))
+
+		for _, r := range records {
+			g, err := r.Game()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			tags := gin.H{}
+			for _, t := range r.Tags {
+				tags[t.Name] = t.Value
+			}
+
+			games = append(games, gin.H{
+				"game":  g.String(),
+				"moves": g.FormatMoves(g.History),
+				"tags":  tags,
+			})
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   games,
+		})
+	})
+
+	s.r.GET("/api/records/export", func(c *gin.Context) {
+		g, err := s.parseGame(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		query := c.QueryMap("tags")
+		names := make([]string, 0, len(query))
+		for name := range query {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		tags := make([]record.Tag, 0, len(names))
+		for _, name := range names {
+			tags = append(tags, record.Tag{Name: name, Value: query[name]})
+		}
+
+		c.String(http.StatusOK, record.FromGame(g, tags...).String())
+	})
+}
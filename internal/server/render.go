@@ -0,0 +1,107 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"image/gif"
+	"image/png"
+	"net/http"
+	"strconv"
+	"tictactoe/internal/game"
+	"tictactoe/internal/render"
+)
+
+func (s *Server) registerRenderRoutes() {
+	s.r.GET("/api/render", func(c *gin.Context) {
+		opts := render.DefaultOptions()
+
+		if cell := c.Query("cell"); cell != "" {
+			size, err := strconv.Atoi(cell)
+			if err != nil || size < render.MinCellSize || size > render.MaxCellSize {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("cell must be between %d and %d", render.MinCellSize, render.MaxCellSize)})
+				return
+			}
+			opts.CellSize = size
+		}
+
+		positions, err := s.parsePositions(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		g := positions[len(positions)-1]
+
+		var buf bytes.Buffer
+
+		switch format := c.DefaultQuery("format", "svg"); format {
+		case "svg":
+			c.Data(http.StatusOK, "image/svg+xml", []byte(render.SVG(g, opts)))
+			return
+		case "png":
+			if opts, err = opts.Fit(g.Size, 1); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			err = png.Encode(&buf, render.Image(g, opts))
+		case "gif":
+			var anim *gif.GIF
+			if anim, err = render.GIF(positions, opts); err == nil {
+				err = gif.EncodeAll(&buf, anim)
+			}
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "unknown format " + format})
+			return
+		}
+
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Data(http.StatusOK, "image/"+c.DefaultQuery("format", "svg"), buf.Bytes())
+	})
+}
+
+// parsePositions reads the game the way parseGame does and returns the start
+// position followed by the position after every move.
+func (s *Server) parsePositions(c *gin.Context) ([]*game.Game, error) {
+	g, err := s.parseStart(c)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := g.GetRules()
+	positions := []*game.Game{g.Copy()}
+
+	g.Rules = recordingRules{Ruleset: rules, positions: &positions}
+	err = playMoves(c, g)
+	g.Rules = rules
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range positions {
+		p.Rules = rules
+	}
+
+	return positions, nil
+}
+
+// recordingRules keeps a copy of the game after every move it applies.
+type recordingRules struct {
+	game.Ruleset
+	positions *[]*game.Game
+}
+
+func (r recordingRules) Apply(g *game.Game, move int) error {
+	if err := r.Ruleset.Apply(g, move); err != nil {
+		return err
+	}
+
+	*r.positions = append(*r.positions, g.Copy())
+
+	return nil
+}
@@ -4,25 +4,48 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"tictactoe/internal/fog"
 	"tictactoe/internal/game"
+	"tictactoe/internal/heuristic"
 	"tictactoe/internal/map_builder"
 	"tictactoe/internal/map_reader"
 	"tictactoe/internal/map_storage"
+	"tictactoe/internal/mcts"
+	"tictactoe/internal/solver"
+	"tictactoe/internal/tt"
+	"tictactoe/internal/variant"
 )
 
 type Server struct {
-	mb *map_builder.MapBuilder
-	mr *map_reader.MapReader
-	r  *gin.Engine
+	mb       *map_builder.MapBuilder
+	mr       *map_reader.MapReader
+	r        *gin.Engine
+	variants map[string]*variant.Variant
+	fog      *fog.Store
+	table    *tt.Table
+	engines  *engines
+	bases    *tablebases
 }
 
-func NewServer() *Server {
+// NewServer returns a server whose searches share a transposition table of
+// the given megabytes.
+func NewServer(hashMegabytes int) *Server {
 	s := &Server{
-		r:  gin.Default(),
-		mb: map_builder.NewMapBuilder(),
-		mr: map_reader.NewMapReader(),
+		r:       gin.Default(),
+		mb:      map_builder.NewMapBuilder(),
+		mr:      map_reader.NewMapReader(),
+		fog:     fog.NewStore(),
+		table:   tt.New(hashMegabytes),
+		engines: newEngines(),
+		bases:   newTablebases(),
 	}
 
+	variants, err := variant.LoadDir("./variants")
+	if err != nil {
+		panic(err)
+	}
+	s.variants = variants
+
 	s.r.Static("/static", "./static")
 
 	s.r.GET("/api/health", func(c *gin.Context) {
@@ -30,9 +53,7 @@ func NewServer() *Server {
 	})
 
 	s.r.GET("/api/maps/status", func(c *gin.Context) {
-		gameStr := c.Query("game")
-
-		g, err := game.FromString(gameStr)
+		g, err := s.parseGame(c)
 		if err != nil {
 			fmt.Println("error parsing game str", err.Error())
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -50,9 +71,7 @@ func NewServer() *Server {
 	})
 
 	s.r.POST("/api/maps/build", func(c *gin.Context) {
-		gameStr := c.Query("game")
-
-		g, err := game.FromString(gameStr)
+		g, err := s.parseGame(c)
 		if err != nil {
 			fmt.Println("error parsing game str", err.Error())
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -75,14 +94,22 @@ func NewServer() *Server {
 	})
 
 	s.r.GET("/api/chances", func(c *gin.Context) {
-		gameStr := c.Query("game")
-
-		g, err := game.FromString(gameStr)
+		g, err := s.parseGame(c)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
+		if tb := s.bases.get(g); tb != nil {
+			if e, ok := tb.Probe(g); ok {
+				c.JSON(http.StatusOK, gin.H{
+					"status": "ok",
+					"data":   perfectChances(g, e),
+				})
+				return
+			}
+		}
+
 		progress, started := map_storage.GetProgress(g)
 		if !started || progress != 100 {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "map is not ready"})
@@ -102,23 +129,85 @@ func NewServer() *Server {
 	})
 
 	s.r.GET("/api/next-move", func(c *gin.Context) {
-		gameStr := c.Query("game")
-
-		g, err := game.FromString(gameStr)
+		g, err := s.parseGame(c)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		x, y, err := s.mr.GetNextMove(g)
+		if phase, _ := g.OpeningPhase(); phase != game.PhasePlay {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "the opening is not over, use /api/opening/engine"})
+			return
+		}
+
+		if g.ExpandMargin > 0 {
+			nextExpandingMove(c, g)
+			return
+		}
+
+		var x, y int
+		var solved *solver.Result
+
+		perfect, inTablebase := s.bases.move(g)
+
+		switch {
+		case !g.GetRules().Placement():
+			var i int
+			i, err = heuristic.BestMove(g)
+			x, y = i%g.Size, i/g.Size
+		case inTablebase:
+			x, y, solved = perfect.Move%g.Size, perfect.Move/g.Size, &perfect
+		case g.Scoring == game.ScoringNone && solver.CanSolve(g):
+			var res solver.Result
+			res, err = solver.New(s.table).Solve(g)
+			x, y, solved = res.Move%g.Size, res.Move/g.Size, &res
+		case g.Size >= mctsMinSize:
+			var res mcts.Result
+			res, err = s.engines.search(g)
+			x, y = res.Move%g.Size, res.Move/g.Size
+		default:
+			x, y, err = s.mr.GetNextMove(g)
+		}
+
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
 
+		data := gin.H{"x": x, "y": y, "move": g.FormatMove(x + y*g.Size)}
+		if solved != nil {
+			data["outcome"] = solved.Outcome
+			data["distance"] = solved.Distance
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   data,
+		})
+	})
+
+	s.registerQuantumRoutes()
+	s.registerInfiniteRoutes()
+	s.registerRecordRoutes()
+	s.registerRenderRoutes()
+	s.registerAnalysisRoutes()
+	s.registerOpeningRoutes()
+	s.registerFogRoutes()
+	s.registerStartPositionRoutes()
+	s.registerTerminationRoutes()
+	s.registerProofRoutes()
+
+	s.r.GET("/api/variants", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   variant.Sorted(s.variants),
+		})
+	})
+
+	s.r.GET("/api/rules", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"status": "ok",
-			"data":   gin.H{"x": x, "y": y},
+			"data":   game.RulesetNames(),
 		})
 	})
 
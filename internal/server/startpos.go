@@ -0,0 +1,62 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"tictactoe/internal/game"
+	"tictactoe/internal/startpos"
+)
+
+func (s *Server) registerStartPositionRoutes() {
+	s.r.GET("/api/startpos", func(c *gin.Context) {
+		size, err := strconv.Atoi(c.DefaultQuery("size", "3"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid size: " + err.Error()})
+			return
+		}
+
+		config := startpos.Config{Size: size, Outcome: startpos.Outcome(c.Query("outcome"))}
+
+		for _, param := range []struct {
+			name string
+			def  int
+			dst  *int
+		}{
+			{"win", game.DefaultWinLength(size), &config.WinLength},
+			{"stones", 1, &config.Stones},
+			{"threshold", 8, &config.Threshold},
+			{"count", 1, &config.Count},
+		} {
+			if *param.dst, err = strconv.Atoi(c.DefaultQuery(param.name, strconv.Itoa(param.def))); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param.name + ": " + err.Error()})
+				return
+			}
+		}
+
+		seed := rand.Int63()
+		if str := c.Query("seed"); str != "" {
+			if seed, err = strconv.ParseInt(str, 10, 64); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid seed: " + err.Error()})
+				return
+			}
+		}
+
+		if config.Count > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "at most 100 positions can be generated at once"})
+			return
+		}
+
+		positions, err := startpos.Generate(config, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   gin.H{"seed": seed, "positions": positions},
+		})
+	})
+}
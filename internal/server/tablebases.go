@@ -0,0 +1,114 @@
+package server
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"sync"
+	"tictactoe/internal/game"
+	"tictactoe/internal/solver"
+	"tictactoe/internal/tablebase"
+)
+
+// tablebasesDir is the directory tablebases are saved to once built.
+const tablebasesDir = "./tablebases"
+
+// tablebases are loaded or built in the background the first time a board
+// asks for one, until then its requests are answered the way they were
+// before.
+type tablebases struct {
+	mu      sync.Mutex
+	ready   map[string]*tablebase.Tablebase
+	loading map[string]bool
+}
+
+func newTablebases() *tablebases {
+	return &tablebases{
+		ready:   map[string]*tablebase.Tablebase{},
+		loading: map[string]bool{},
+	}
+}
+
+// get returns the tablebase of the board of the game, or nil when it is not
+// ready yet.
+func (t *tablebases) get(g *game.Game) *tablebase.Tablebase {
+	if !tablebase.Supported(g) {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := g.GetMapKey()
+	if tb, ok := t.ready[key]; ok {
+		return tb
+	}
+
+	if !t.loading[key] {
+		t.loading[key] = true
+		go t.load(tablebase.Empty(g), key)
+	}
+
+	return nil
+}
+
+func (t *tablebases) load(g *game.Game, key string) {
+	path := tablebase.Path(tablebasesDir, g)
+
+	tb, err := tablebase.Load(path, g)
+	if err != nil {
+		fmt.Println("building tablebase", key)
+
+		if tb, err = tablebase.Build(g); err != nil {
+			fmt.Println("failed to build tablebase", key, err)
+			return
+		}
+
+		if err := tb.Save(path); err != nil {
+			fmt.Println("failed to save tablebase", key, err)
+		}
+	}
+
+	t.mu.Lock()
+	t.ready[key] = tb
+	t.mu.Unlock()
+}
+
+// move returns the best move of the position from its tablebase, in the
+// shape of a solver result.
+func (t *tablebases) move(g *game.Game) (solver.Result, bool) {
+	tb := t.get(g)
+	if tb == nil {
+		return solver.Result{}, false
+	}
+
+	i, e, err := tb.BestMove(g)
+	if err != nil {
+		return solver.Result{}, false
+	}
+
+	return solver.Result{Move: i, Outcome: solver.Outcome(e.Value.String()), Distance: int(e.Depth)}, true
+}
+
+// perfectChances returns the chances of X under perfect play, the outcome
+// of the game is certain.
+func perfectChances(g *game.Game, e tablebase.Entry) gin.H {
+	winner := game.PlayerNone
+	switch e.Value {
+	case tablebase.ValueWin:
+		winner = g.PlayerTurn
+	case tablebase.ValueLoss:
+		winner = g.PlayerTurn.Opponent()
+	}
+
+	res := gin.H{"win": 0, "lose": 0, "draw": 0, "depth": e.Depth}
+	switch winner {
+	case game.PlayerX:
+		res["win"] = 1
+	case game.PlayerO:
+		res["lose"] = 1
+	default:
+		res["draw"] = 1
+	}
+
+	return res
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"tictactoe/internal/game"
+)
+
+func (s *Server) registerTerminationRoutes() {
+	s.r.GET("/api/state", func(c *gin.Context) {
+		g, err := s.parseGame(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"data":   gameState(g),
+		})
+	})
+
+	for path, forfeit := range map[string]func(*game.Game, game.Player) error{
+		"/api/resign":  (*game.Game).Resign,
+		"/api/timeout": (*game.Game).Timeout,
+	} {
+		s.r.GET(path, func(c *gin.Context) {
+			g, err := s.parseGame(c)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			player := g.PlayerTurn
+			if str := c.Query("player"); str != "" {
+				player = game.Player(str[0])
+			}
+
+			if err := forfeit(g, player); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{
+				"status": "ok",
+				"data":   gameState(g),
+			})
+		})
+	}
+}
+
+func gameState(g *game.Game) gin.H {
+	return gin.H{
+		"game":        g.String(),
+		"over":        g.IsOver(),
+		"won":         string(g.PlayerWon),
+		"termination": g.Termination(),
+		"turn":        string(g.PlayerTurn),
+	}
+}
@@ -0,0 +1,275 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"tictactoe/internal/game"
+	"tictactoe/internal/heuristic"
+	"tictactoe/internal/tt"
+)
+
+// MaxCells is the number of empty cells up to which positions are solved
+// directly, enough for every position of 4x4.
+const MaxCells = 16
+
+// scoreWin is the value of a win on the spot, every ply to the end of the
+// game takes one off.
+const scoreWin = 1 << 16
+
+type Outcome string
+
+const (
+	OutcomeWin  Outcome = "win"
+	OutcomeDraw Outcome = "draw"
+	OutcomeLoss Outcome = "loss"
+)
+
+// Value is the exact game value of a position for the player to move:
+// positive for a win, negative for a loss and 0 for a draw.
+type Value int
+
+func (v Value) Outcome() Outcome {
+	switch {
+	case v > 0:
+		return OutcomeWin
+	case v < 0:
+		return OutcomeLoss
+	default:
+		return OutcomeDraw
+	}
+}
+
+// Distance returns the number of plies to the end of a decided game under
+// perfect play, where the winner hurries and the loser delays. It is 0 for
+// draws.
+func (v Value) Distance() int {
+	switch {
+	case v > 0:
+		return scoreWin - int(v)
+	case v < 0:
+		return scoreWin + int(v)
+	default:
+		return 0
+	}
+}
+
+func (v Value) String() string {
+	if v == 0 {
+		return string(OutcomeDraw)
+	}
+
+	return fmt.Sprintf("%s in %d", v.Outcome(), v.Distance())
+}
+
+// Result is the best move of a position together with its value.
+type Result struct {
+	Move     int     `json:"move"`
+	Value    Value   `json:"-"`
+	Outcome  Outcome `json:"outcome"`
+	Distance int     `json:"distance"`
+	Nodes    uint64  `json:"nodes"`
+}
+
+// solveMegabytes is the memory of the table of a solver of its own.
+const solveMegabytes = 16
+
+// Solver searches positions to the end with negamax and alpha-beta pruning.
+// Results are kept in a transposition table, which may be shared by the
+// solvers of concurrent searches and reused for the moves of one game.
+type Solver struct {
+	table *tt.Table
+	nodes uint64
+}
+
+func New(table *tt.Table) *Solver {
+	return &Solver{table: table}
+}
+
+// CanSolve reports whether the position is small enough to be solved.
+func CanSolve(g *game.Game) bool {
+	return len(g.GetRules().LegalMoves(g)) <= MaxCells
+}
+
+// Solve returns the best move of the position with a new solver and table.
+func Solve(g *game.Game) (Result, error) {
+	return New(tt.New(solveMegabytes)).Solve(g)
+}
+
+// Solve returns the best move of the position and its value for the player
+// to move. Among equal moves the first one is taken, so the winner picks
+// the quickest win and the loser the longest defence.
+func (s *Solver) Solve(g *game.Game) (Result, error) {
+	rules := g.GetRules()
+	if rules.IsTerminal(g) {
+		return Result{}, errors.New("game is already over")
+	}
+
+	if !CanSolve(g) {
+		return Result{}, fmt.Errorf("only positions with at most %d empty cells can be solved", MaxCells)
+	}
+
+	search := g.Copy()
+	search.Adjudicate = false
+
+	s.nodes = 0
+	s.table.NewSearch()
+	v, move := s.negamax(search, -scoreWin-1, scoreWin+1, 0)
+
+	if move < 0 {
+		return Result{}, errors.New("no moves left")
+	}
+
+	return Result{
+		Move:     move,
+		Value:    v,
+		Outcome:  v.Outcome(),
+		Distance: v.Distance(),
+		Nodes:    s.nodes,
+	}, nil
+}
+
+// Value returns the game value of the position for the player to move.
+func (s *Solver) Value(g *game.Game) (Value, error) {
+	if g.GetRules().IsTerminal(g) {
+		return terminalValue(g), nil
+	}
+
+	res, err := s.Solve(g)
+
+	return res.Value, err
+}
+
+// negamax returns the value of the position and its best move. The table
+// is not cut off at the root, so its move is always one of the position.
+func (s *Solver) negamax(g *game.Game, alpha, beta Value, ply int) (Value, int) {
+	s.nodes++
+
+	rules := g.GetRules()
+	if rules.IsTerminal(g) {
+		return terminalValue(g), -1
+	}
+
+	moves := rules.LegalMoves(g)
+	depth := len(moves)
+	key := g.Hash()
+	hashMove := -1
+
+	if e, ok := s.table.Probe(key); ok {
+		hashMove = e.Move
+		v := Value(e.Value)
+
+		if ply > 0 && e.Depth >= depth {
+			switch {
+			case e.Bound == tt.BoundExact:
+				return v, e.Move
+			case e.Bound == tt.BoundLower && v >= beta:
+				return v, e.Move
+			case e.Bound == tt.BoundUpper && v <= alpha:
+				return v, e.Move
+			}
+		}
+	}
+
+	origAlpha := alpha
+	best, bestMove := Value(-scoreWin-1), -1
+
+	for _, i := range orderMoves(g, moves, hashMove) {
+		next := g.Copy()
+		if err := rules.Apply(next, i); err != nil {
+			panic(err)
+		}
+
+		// values are for the side to move, which a turn of several stones
+		// keeps
+		var v Value
+		if next.PlayerTurn == g.PlayerTurn {
+			v, _ = s.negamax(next, unstep(alpha), unstep(beta), ply+1)
+		} else {
+			v, _ = s.negamax(next, -unstep(beta), -unstep(alpha), ply+1)
+			v = -v
+		}
+
+		if v = step(v); v > best {
+			best, bestMove = v, i
+		}
+
+		if best > alpha {
+			alpha = best
+		}
+
+		if alpha >= beta {
+			break
+		}
+	}
+
+	e := tt.Entry{Depth: depth, Bound: tt.BoundExact, Value: int(best), Move: bestMove}
+	switch {
+	case best <= origAlpha:
+		e.Bound = tt.BoundUpper
+	case best >= beta:
+		e.Bound = tt.BoundLower
+	}
+	s.table.Store(key, e)
+
+	return best, bestMove
+}
+
+// orderMoves puts the move of an earlier search first, followed by the
+// moves the heuristic rates best.
+func orderMoves(g *game.Game, moves []int, first int) []int {
+	windows := heuristic.CellWindows(g)
+	scores := make(map[int]int, len(moves))
+
+	for _, i := range moves {
+		scores[i] = heuristic.ScoreMove(g, windows, i)
+	}
+
+	slices.SortStableFunc(moves, func(a, b int) int {
+		switch {
+		case a == first:
+			return -1
+		case b == first:
+			return 1
+		default:
+			return scores[b] - scores[a]
+		}
+	})
+
+	return moves
+}
+
+func terminalValue(g *game.Game) Value {
+	switch g.GetRules().Outcome(g) {
+	case game.PlayerNone:
+		return 0
+	case g.PlayerTurn:
+		return scoreWin
+	default:
+		return -scoreWin
+	}
+}
+
+// step turns the value of a child into the value one ply before it.
+func step(v Value) Value {
+	switch {
+	case v > 0:
+		return v - 1
+	case v < 0:
+		return v + 1
+	default:
+		return 0
+	}
+}
+
+// unstep is the inverse of step, used to pass the bounds to a child.
+func unstep(v Value) Value {
+	switch {
+	case v > 0:
+		return v + 1
+	case v < 0:
+		return v - 1
+	default:
+		return 0
+	}
+}
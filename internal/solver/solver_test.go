@@ -0,0 +1,122 @@
+package solver
+
+import (
+	"math/rand"
+	"testing"
+	"tictactoe/internal/game"
+	"tictactoe/internal/tt"
+)
+
+func TestSolveEmpty(t *testing.T) {
+	for _, size := range []int{3, 4} {
+		g, _ := game.NewGame(size, size)
+
+		res, err := Solve(g)
+		if err != nil {
+			t.Fatalf("Failed to solve %dx%d: %v", size, size, err)
+		}
+
+		if res.Outcome != OutcomeDraw {
+			t.Fatalf("Expected %dx%d to be a draw, got %s", size, size, res.Value)
+		}
+	}
+}
+
+func TestSolveQuickestWin(t *testing.T) {
+	// X wins at once on c3, or later in other ways
+	g, _ := game.FromString("_ XX_OO____")
+
+	res, err := Solve(g)
+	if err != nil {
+		t.Fatalf("Failed to solve: %v", err)
+	}
+
+	if res.Move != 2 || res.Outcome != OutcomeWin || res.Distance != 1 {
+		t.Fatalf("Expected a win in 1 on 2, got %d %s", res.Move, res.Value)
+	}
+}
+
+func TestSolveLoss(t *testing.T) {
+	// O can only block one of the two lines of X
+	g, _ := game.FromString("_ X_X_O_X__ turn=O")
+
+	res, err := Solve(g)
+	if err != nil {
+		t.Fatalf("Failed to solve: %v", err)
+	}
+
+	if res.Outcome != OutcomeLoss || res.Distance != 2 {
+		t.Fatalf("Expected a loss in 2, got %s", res.Value)
+	}
+}
+
+func TestSolveStonesPerTurn(t *testing.T) {
+	g, _ := game.NewGame(4, 3)
+	g.Directions = []game.Point{{X: 1, Y: 0}, {X: 0, Y: 1}}
+	g.SetTurnOrder(game.PlayerX, 2)
+
+	if err := g.PlayMoves("a1 b1 a4 b4"); err != nil {
+		t.Fatalf("Failed to play moves: %v", err)
+	}
+
+	// the first of the two stones of X completes a1 b1 c1
+	res, err := Solve(g)
+	if err != nil {
+		t.Fatalf("Failed to solve: %v", err)
+	}
+
+	if g.FormatMove(res.Move) != "c1" || res.Outcome != OutcomeWin || res.Distance != 1 {
+		t.Fatalf("Expected a win in 1 on c1, got %s %s", g.FormatMove(res.Move), res.Value)
+	}
+}
+
+func TestSolveMatchesPerfectOutcome(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := New(tt.New(1))
+
+	for n := 0; n < 50; n++ {
+		g, _ := game.NewGame(3, 3)
+
+		for plies := rng.Intn(6); plies > 0 && !g.IsOver(); plies-- {
+			moves := g.GetRules().LegalMoves(g)
+			g.MakeMoveByIndex(moves[rng.Intn(len(moves))])
+		}
+
+		if g.IsOver() {
+			continue
+		}
+
+		v, err := s.Value(g)
+		if err != nil {
+			t.Fatalf("Failed to solve %s: %v", g, err)
+		}
+
+		winner, _ := g.PerfectOutcome(MaxCells)
+
+		var expected Outcome
+		switch winner {
+		case game.PlayerNone:
+			expected = OutcomeDraw
+		case g.PlayerTurn:
+			expected = OutcomeWin
+		default:
+			expected = OutcomeLoss
+		}
+
+		if v.Outcome() != expected {
+			t.Fatalf("Expected %s for %s, got %s", expected, g, v)
+		}
+	}
+}
+
+func TestSolveReusesTable(t *testing.T) {
+	table := tt.New(1)
+	g, _ := game.NewGame(3, 3)
+
+	first, _ := New(table).Solve(g)
+	again, _ := New(table).Solve(g)
+
+	if again.Nodes >= first.Nodes || again.Value != first.Value {
+		t.Fatalf("Expected the second search to reuse the table, got %d and %d nodes", first.Nodes, again.Nodes)
+	}
+}
@@ -0,0 +1,150 @@
+package startpos
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"tictactoe/internal/game"
+	"tictactoe/internal/heuristic"
+)
+
+// MaxSolveCells is the number of empty cells up to which positions are
+// solved exactly instead of only being evaluated.
+const MaxSolveCells = 12
+
+// MaxAttempts bounds the default number of random positions tried.
+const MaxAttempts = 10000
+
+// Outcome a generated position has to have under perfect play.
+type Outcome string
+
+const (
+	OutcomeAny   Outcome = ""
+	OutcomeDraw  Outcome = "draw"
+	OutcomeXWins Outcome = "x"
+	OutcomeOWins Outcome = "o"
+)
+
+type Config struct {
+	Size      int
+	WinLength int
+	// Stones is the number of pre-placed stones per side, X moves next.
+	Stones int
+	// Threshold is the maximum absolute heuristic.Evaluate of a position.
+	Threshold int
+	Outcome   Outcome
+	Count     int
+	// Attempts limits the random positions tried, 1000 per position up to
+	// MaxAttempts by default.
+	Attempts int
+}
+
+type Position struct {
+	Game string `json:"game"`
+	// WinLength is not part of the game string, which only tells the default
+	// win length of the board size.
+	WinLength  int `json:"winLength"`
+	Evaluation int `json:"evaluation"`
+	// Value is the solved outcome, empty when the board is too large.
+	Value Outcome `json:"value,omitempty"`
+}
+
+func (c Config) Validate() error {
+	if c.Size < 1 || c.Size > game.MaxSize || c.WinLength < 1 || c.WinLength > c.Size {
+		return fmt.Errorf("invalid board size %d with win length %d", c.Size, c.WinLength)
+	}
+
+	if c.Stones < 0 || 2*c.Stones >= c.Size*c.Size {
+		return fmt.Errorf("%d stones per side do not fit the board", c.Stones)
+	}
+
+	switch c.Outcome {
+	case OutcomeAny, OutcomeDraw, OutcomeXWins, OutcomeOWins:
+	default:
+		return fmt.Errorf("unknown outcome %q", c.Outcome)
+	}
+
+	if c.Outcome != OutcomeAny && c.Size*c.Size-2*c.Stones > MaxSolveCells {
+		return fmt.Errorf("positions with more than %d empty cells can not be solved", MaxSolveCells)
+	}
+
+	return nil
+}
+
+// Generate returns Count distinct random positions which pass the balance
+// threshold and, when requested, have the given outcome.
+func Generate(c Config, rng *rand.Rand) ([]Position, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	attempts := c.Attempts
+	if attempts <= 0 {
+		attempts = min(1000*max(c.Count, 1), MaxAttempts)
+	}
+
+	seen := map[string]bool{}
+	var res []Position
+
+	for i := 0; i < attempts && len(res) < c.Count; i++ {
+		g := random(c, rng)
+		if g == nil || seen[g.String()] {
+			continue
+		}
+		seen[g.String()] = true
+
+		pos, ok := check(c, g)
+		if ok {
+			res = append(res, pos)
+		}
+	}
+
+	if len(res) < c.Count {
+		return res, errors.New("not enough positions found, loosen the threshold or add attempts")
+	}
+
+	return res, nil
+}
+
+// random places the stones alternately on random empty cells. Positions
+// which are already decided are discarded.
+func random(c Config, rng *rand.Rand) *game.Game {
+	g, _ := game.NewGame(c.Size, c.WinLength)
+
+	for _, i := range rng.Perm(len(g.Board))[:2*c.Stones] {
+		g.MakeMoveByIndex(i)
+		if g.IsOver() {
+			return nil
+		}
+	}
+
+	g.History = nil
+
+	return g
+}
+
+func check(c Config, g *game.Game) (Position, bool) {
+	pos := Position{Game: g.String(), WinLength: g.WinLength, Evaluation: heuristic.Evaluate(g)}
+
+	if pos.Evaluation > c.Threshold || pos.Evaluation < -c.Threshold {
+		return pos, false
+	}
+
+	if empty := len(g.GetRules().LegalMoves(g)); empty <= MaxSolveCells {
+		pos.Value = solve(g)
+	}
+
+	return pos, c.Outcome == OutcomeAny || pos.Value == c.Outcome
+}
+
+// solve returns the outcome of the game under perfect play.
+func solve(g *game.Game) Outcome {
+	switch winner, _ := g.PerfectOutcome(MaxSolveCells); winner {
+	case game.PlayerX:
+		return OutcomeXWins
+	case game.PlayerO:
+		return OutcomeOWins
+	default:
+		return OutcomeDraw
+	}
+}
@@ -0,0 +1,70 @@
+package startpos
+
+import (
+	"math/rand"
+	"testing"
+	"tictactoe/internal/game"
+)
+
+func TestGenerate(t *testing.T) {
+	c := Config{Size: 3, WinLength: 3, Stones: 1, Threshold: 8, Outcome: OutcomeDraw, Count: 3}
+
+	positions, err := Generate(c, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to generate positions: %v", err)
+	}
+
+	if len(positions) != 3 {
+		t.Fatalf("Expected 3 positions, got %d", len(positions))
+	}
+
+	for _, pos := range positions {
+		g, err := game.FromString(pos.Game)
+		if err != nil {
+			t.Fatalf("Failed to parse generated position %s: %v", pos.Game, err)
+		}
+
+		if g.StepsCount != 2 || g.PlayerTurn != game.PlayerX || pos.Value != OutcomeDraw {
+			t.Fatalf("Unexpected position %s with value %q", pos.Game, pos.Value)
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	g, _ := game.FromString("_ X___O____")
+	if v := solve(g); v != OutcomeDraw {
+		t.Fatalf("Expected a draw, got %q", v)
+	}
+
+	g, _ = game.FromString("_ XO_______")
+	if v := solve(g); v != OutcomeXWins {
+		t.Fatalf("Expected X to win, got %q", v)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := (Config{Size: 7, WinLength: 5, Stones: 2, Outcome: OutcomeDraw}).Validate(); err == nil {
+		t.Fatalf("Expected an error for a board too large to solve")
+	}
+
+	if err := (Config{Size: 100000, WinLength: 5, Count: 1}).Validate(); err == nil {
+		t.Fatalf("Expected an error for a board larger than the largest map")
+	}
+}
+
+func TestGenerateWinLength(t *testing.T) {
+	c := Config{Size: 4, WinLength: 3, Stones: 2, Threshold: 100, Outcome: OutcomeAny, Count: 1}
+
+	positions, err := Generate(c, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to generate positions: %v", err)
+	}
+
+	if positions[0].WinLength != 3 {
+		t.Fatalf("Expected the win length 3 of the config, got %d", positions[0].WinLength)
+	}
+
+	if err := (Config{Size: 3, WinLength: 3, Outcome: "X"}).Validate(); err == nil {
+		t.Fatalf("Expected an error for an unknown outcome")
+	}
+}
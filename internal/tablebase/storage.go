@@ -0,0 +1,56 @@
+package tablebase
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"tictactoe/internal/game"
+)
+
+// Path returns the file of the tablebase of the game in the directory.
+func Path(dir string, g *game.Game) string {
+	return filepath.Join(dir, g.GetMapKey()+".tb")
+}
+
+func (tb *Tablebase) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+
+	if err := gob.NewEncoder(file).Encode(tb); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write tablebase: %v", err)
+	}
+
+	return file.Close()
+}
+
+// Load reads the tablebase of the game from the file.
+func Load(path string, g *game.Game) (*Tablebase, error) {
+	tb, err := newTablebase(g)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	if err := gob.NewDecoder(file).Decode(tb); err != nil {
+		return nil, fmt.Errorf("failed to read tablebase: %v", err)
+	}
+
+	if tb.Key != g.GetMapKey() {
+		return nil, fmt.Errorf("tablebase is of %s, not %s", tb.Key, g.GetMapKey())
+	}
+
+	return tb, nil
+}
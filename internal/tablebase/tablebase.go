@@ -0,0 +1,330 @@
+package tablebase
+
+import (
+	"errors"
+	"fmt"
+	"tictactoe/internal/game"
+)
+
+// MaxCells is the largest board a tablebase is built for, 4x4.
+const MaxCells = 16
+
+// Value is the game value of a position for the player to move.
+type Value int8
+
+const (
+	ValueLoss Value = -1
+	ValueDraw Value = 0
+	ValueWin  Value = 1
+)
+
+func (v Value) String() string {
+	switch v {
+	case ValueWin:
+		return "win"
+	case ValueLoss:
+		return "loss"
+	default:
+		return "draw"
+	}
+}
+
+func (v Value) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// Entry is the value of a position and its depth to end: the plies to the
+// end of the game under perfect play, where the winner hurries and the
+// loser delays.
+type Entry struct {
+	Value Value `json:"value"`
+	Depth uint8 `json:"depth"`
+}
+
+// Tablebase holds the value of every position reachable from the empty
+// board. Positions which are symmetric images of each other share one
+// entry, keyed by the smallest base 3 code of their images.
+type Tablebase struct {
+	Key     string
+	Entries map[uint64]Entry
+
+	empty *game.Game
+	perms [][]int
+	pow3  []uint64
+}
+
+// Supported reports whether a tablebase can be built for the board of the
+// game, which needs a small board where the players take turns with single
+// stones and nothing but the stones makes up a position.
+func Supported(g *game.Game) bool {
+	return g.GetRules().Placement() && len(g.Board) <= MaxCells && g.Scoring == game.ScoringNone &&
+		g.StonesPerTurn <= 1 && g.Opening == game.OpeningNone && g.ExpandMargin == 0
+}
+
+func newTablebase(g *game.Game) (*Tablebase, error) {
+	if !Supported(g) {
+		return nil, fmt.Errorf("tablebases are only built for boards of up to %d cells under the standard rules", MaxCells)
+	}
+
+	tb := &Tablebase{
+		Key:     g.GetMapKey(),
+		Entries: map[uint64]Entry{},
+		empty:   Empty(g),
+		pow3:    make([]uint64, len(g.Board)),
+	}
+
+	tb.perms = tb.empty.Symmetries()
+
+	for i := range tb.pow3 {
+		tb.pow3[i] = 1
+		if i > 0 {
+			tb.pow3[i] = tb.pow3[i-1] * 3
+		}
+	}
+
+	return tb, nil
+}
+
+// Empty returns the empty board of the game, keeping its variant and its
+// blocked cells.
+func Empty(g *game.Game) *game.Game {
+	res := g.Copy()
+
+	for i, p := range res.Board {
+		if p == game.PlayerX || p == game.PlayerO {
+			res.Board[i] = game.PlayerNone
+		}
+	}
+
+	res.PlayerTurn = game.PlayerX
+	if g.FirstPlayer == game.PlayerO {
+		res.PlayerTurn = game.PlayerO
+	}
+
+	res.PlayerWon = game.PlayerNone
+	res.StepsCount = 0
+	res.History = nil
+	res.Ended = game.TerminationNone
+	res.Adjudicate = false
+
+	return res
+}
+
+// Build enumerates the positions reachable from the empty board of the game
+// ply by ply, then solves them by retrograde analysis: from the last ply
+// back to the first every position takes its value from the positions its
+// moves lead to, which are solved already.
+func Build(g *game.Game) (*Tablebase, error) {
+	tb, err := newTablebase(g)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := tb.empty.GetRules()
+	layers := [][]uint64{{tb.code(tb.empty.Board)}}
+	seen := map[uint64]bool{layers[0][0]: true}
+
+	for ply := 0; len(layers[ply]) > 0; ply++ {
+		var next []uint64
+
+		for _, code := range layers[ply] {
+			pos := tb.position(code)
+			if rules.IsTerminal(pos) {
+				continue
+			}
+
+			for _, child := range children(pos) {
+				if c := tb.code(child.Board); !seen[c] {
+					seen[c] = true
+					next = append(next, c)
+				}
+			}
+		}
+
+		layers = append(layers, next)
+	}
+
+	for ply := len(layers) - 1; ply >= 0; ply-- {
+		for _, code := range layers[ply] {
+			e, err := tb.solve(tb.position(code))
+			if err != nil {
+				return nil, err
+			}
+
+			tb.Entries[code] = e
+		}
+	}
+
+	return tb, nil
+}
+
+// solve returns the entry of a position from the entries of its children.
+func (tb *Tablebase) solve(pos *game.Game) (Entry, error) {
+	rules := pos.GetRules()
+
+	if rules.IsTerminal(pos) {
+		switch rules.Outcome(pos) {
+		case game.PlayerNone:
+			return Entry{Value: ValueDraw}, nil
+		case pos.PlayerTurn:
+			return Entry{Value: ValueWin}, nil
+		default:
+			return Entry{Value: ValueLoss}, nil
+		}
+	}
+
+	var best Entry
+	found := false
+
+	for _, child := range children(pos) {
+		e, ok := tb.Entries[tb.code(child.Board)]
+		if !ok {
+			return Entry{}, errors.New("child position is not solved")
+		}
+
+		e = Entry{Value: -e.Value, Depth: e.Depth + 1}
+		if !found || better(e, best) {
+			best, found = e, true
+		}
+	}
+
+	return best, nil
+}
+
+// better reports whether a is a better entry for the player to move than b.
+func better(a, b Entry) bool {
+	switch {
+	case a.Value != b.Value:
+		return a.Value > b.Value
+	case a.Value == ValueWin:
+		return a.Depth < b.Depth
+	default:
+		return a.Depth > b.Depth
+	}
+}
+
+// Probe returns the entry of the position.
+func (tb *Tablebase) Probe(g *game.Game) (Entry, bool) {
+	if g.GetMapKey() != tb.Key || len(g.Board) != len(tb.empty.Board) {
+		return Entry{}, false
+	}
+
+	stones := 0
+	for _, p := range g.Board {
+		if p == game.PlayerX || p == game.PlayerO {
+			stones++
+		}
+	}
+
+	if g.PlayerTurn != tb.turn(stones) {
+		return Entry{}, false
+	}
+
+	e, ok := tb.Entries[tb.code(g.Board)]
+
+	return e, ok
+}
+
+// BestMove returns the move of the position leading to the best entry for
+// the player to move, and the entry of the position.
+func (tb *Tablebase) BestMove(g *game.Game) (int, Entry, error) {
+	e, ok := tb.Probe(g)
+	if !ok {
+		return 0, Entry{}, errors.New("position is not in the tablebase")
+	}
+
+	rules := g.GetRules()
+	moves := rules.LegalMoves(g)
+	if len(moves) == 0 {
+		return 0, Entry{}, errors.New("no moves left")
+	}
+
+	for _, i := range moves {
+		child := g.Copy()
+		if err := rules.Apply(child, i); err != nil {
+			return 0, Entry{}, err
+		}
+
+		if c, ok := tb.Probe(child); ok && -c.Value == e.Value && c.Depth+1 == e.Depth {
+			return i, e, nil
+		}
+	}
+
+	return 0, Entry{}, errors.New("no move keeps the value")
+}
+
+// code returns the smallest base 3 code of the symmetric images of the
+// board, where empty and blocked cells are 0, X is 1 and O is 2.
+func (tb *Tablebase) code(board []game.Player) uint64 {
+	var res uint64
+
+	for k, perm := range tb.perms {
+		var c uint64
+
+		for i, p := range board {
+			switch p {
+			case game.PlayerX:
+				c += tb.pow3[perm[i]]
+			case game.PlayerO:
+				c += 2 * tb.pow3[perm[i]]
+			}
+		}
+
+		if k == 0 || c < res {
+			res = c
+		}
+	}
+
+	return res
+}
+
+// position returns the game of a code, the player to move follows from the
+// number of stones.
+func (tb *Tablebase) position(code uint64) *game.Game {
+	pos := tb.empty.Copy()
+	stones := 0
+
+	for i := range pos.Board {
+		switch code % 3 {
+		case 1:
+			pos.Board[i] = game.PlayerX
+			stones++
+		case 2:
+			pos.Board[i] = game.PlayerO
+			stones++
+		}
+
+		code /= 3
+	}
+
+	pos.StepsCount = stones
+	pos.PlayerTurn = tb.turn(stones)
+	pos.CheckWin()
+
+	return pos
+}
+
+func (tb *Tablebase) turn(stones int) game.Player {
+	if stones%2 == 1 {
+		return tb.empty.PlayerTurn.Opponent()
+	}
+
+	return tb.empty.PlayerTurn
+}
+
+func children(pos *game.Game) []*game.Game {
+	rules := pos.GetRules()
+	moves := rules.LegalMoves(pos)
+	res := make([]*game.Game, 0, len(moves))
+
+	for _, i := range moves {
+		child := pos.Copy()
+		if err := rules.Apply(child, i); err != nil {
+			panic(err)
+		}
+
+		res = append(res, child)
+	}
+
+	return res
+}
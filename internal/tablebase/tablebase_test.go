@@ -0,0 +1,101 @@
+package tablebase
+
+import (
+	"math/rand"
+	"testing"
+	"tictactoe/internal/game"
+	"tictactoe/internal/solver"
+)
+
+func TestBuild(t *testing.T) {
+	g, _ := game.NewGame(3, 3)
+
+	tb, err := Build(g)
+	if err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	// the 5478 positions of 3x3 are 765 up to symmetry
+	if len(tb.Entries) != 765 {
+		t.Fatalf("Expected 765 positions, got %d", len(tb.Entries))
+	}
+
+	if e, ok := tb.Probe(g); !ok || e != (Entry{Value: ValueDraw, Depth: 9}) {
+		t.Fatalf("Expected a draw in 9 for the empty board, got %v", e)
+	}
+}
+
+func TestMatchesSolver(t *testing.T) {
+	g, _ := game.NewGame(3, 3)
+	tb, _ := Build(g)
+	rng := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 100; n++ {
+		pos, _ := game.NewGame(3, 3)
+
+		for plies := rng.Intn(7); plies > 0 && !pos.IsOver(); plies-- {
+			moves := pos.GetRules().LegalMoves(pos)
+			pos.MakeMoveByIndex(moves[rng.Intn(len(moves))])
+		}
+
+		if pos.IsOver() {
+			continue
+		}
+
+		e, ok := tb.Probe(pos)
+		if !ok {
+			t.Fatalf("Expected %s to be in the tablebase", pos)
+		}
+
+		res, _ := solver.Solve(pos)
+		if e.Value.String() != string(res.Outcome) || (e.Value != ValueDraw && int(e.Depth) != res.Distance) {
+			t.Fatalf("Expected %s in %d for %s, got %v", res.Outcome, res.Distance, pos, e)
+		}
+	}
+}
+
+func TestBestMove(t *testing.T) {
+	g, _ := game.NewGame(3, 3)
+	tb, _ := Build(g)
+
+	pos, _ := game.FromString("_ XX_OO____")
+
+	i, e, err := tb.BestMove(pos)
+	if err != nil || i != 2 || e != (Entry{Value: ValueWin, Depth: 1}) {
+		t.Fatalf("Expected a win in 1 on 2, got %d %v %v", i, e, err)
+	}
+
+	pos, _ = game.FromString("_ ____X____ turn=X")
+	if _, ok := tb.Probe(pos); ok {
+		t.Fatalf("Expected a position with the wrong player to move not to be found")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	g, _ := game.NewGame(3, 3)
+	g.Misere = true
+
+	tb, err := Build(g)
+	if err != nil {
+		t.Fatalf("Failed to build: %v", err)
+	}
+
+	path := Path(t.TempDir(), g)
+	if err := tb.Save(path); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	loaded, err := Load(path, g)
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+
+	if e, ok := loaded.Probe(g); !ok || e.Value != ValueDraw || len(loaded.Entries) != len(tb.Entries) {
+		t.Fatalf("Expected the misère board to be a draw, got %v", e)
+	}
+
+	other, _ := game.NewGame(3, 3)
+	if _, err := Load(path, other); err == nil {
+		t.Fatalf("Expected an error for the tablebase of another board")
+	}
+}
@@ -0,0 +1,260 @@
+package tss
+
+import (
+	"slices"
+	"tictactoe/internal/game"
+)
+
+// searcher keeps the stones of both sides in every win position, so threats
+// are found by counting instead of looking at the board.
+type searcher struct {
+	kind        Kind
+	board       []game.Player
+	windows     [][]int
+	cellWindows [][]int
+	counts      [2][]int
+	failed      map[string]int
+	nodes       uint64
+}
+
+var players = [2]game.Player{game.PlayerX, game.PlayerO}
+
+func newSearcher(g *game.Game, kind Kind) *searcher {
+	s := &searcher{
+		kind:        kind,
+		board:       slices.Clone(g.Board),
+		windows:     g.GetWinPositions(),
+		cellWindows: make([][]int, len(g.Board)),
+		failed:      map[string]int{},
+	}
+
+	s.counts = [2][]int{make([]int, len(s.windows)), make([]int, len(s.windows))}
+
+	for w, positions := range s.windows {
+		for _, i := range positions {
+			s.cellWindows[i] = append(s.cellWindows[i], w)
+
+			switch s.board[i] {
+			case game.PlayerX:
+				s.counts[0][w]++
+			case game.PlayerO:
+				s.counts[1][w]++
+			}
+		}
+	}
+
+	return s
+}
+
+func (s *searcher) place(i, a int) {
+	s.board[i] = players[a]
+	for _, w := range s.cellWindows[i] {
+		s.counts[a][w]++
+	}
+}
+
+func (s *searcher) remove(i, a int) {
+	s.board[i] = game.PlayerNone
+	for _, w := range s.cellWindows[i] {
+		s.counts[a][w]--
+	}
+}
+
+// open reports whether only side a has stones in the window, at least n.
+func (s *searcher) open(w, a, n int) bool {
+	return s.counts[1-a][w] == 0 && s.counts[a][w] >= n
+}
+
+// cells returns the empty cells of the windows side a lacks at most short
+// stones of.
+func (s *searcher) cells(a, short int) []int {
+	var res []int
+
+	for w, positions := range s.windows {
+		if !s.open(w, a, len(positions)-short) {
+			continue
+		}
+
+		for _, i := range positions {
+			if s.board[i] == game.PlayerNone && !slices.Contains(res, i) {
+				res = append(res, i)
+			}
+		}
+	}
+
+	slices.Sort(res)
+
+	return res
+}
+
+// winningCells returns the cells which complete a line of side a.
+func (s *searcher) winningCells(a int) []int {
+	return s.cells(a, 1)
+}
+
+// attack returns the main line of a forced win of side a, to move, with up
+// to depth threats.
+func (s *searcher) attack(a, depth int) ([]int, bool) {
+	s.nodes++
+
+	if wins := s.winningCells(a); len(wins) > 0 {
+		return []int{wins[0]}, true
+	}
+
+	if depth == 0 || s.nodes >= MaxNodes {
+		return nil, false
+	}
+
+	key := string(s.board) + string(players[a])
+	if d, ok := s.failed[key]; ok && d >= depth {
+		return nil, false
+	}
+
+	blocks := s.winningCells(1 - a)
+	if len(blocks) > 1 {
+		return nil, false
+	}
+
+	for _, i := range s.threats(a) {
+		if len(blocks) == 1 && i != blocks[0] {
+			continue
+		}
+
+		s.place(i, a)
+		line, ok := s.defend(a, depth)
+		s.remove(i, a)
+
+		if ok {
+			return append([]int{i}, line...), true
+		}
+	}
+
+	s.failed[key] = depth
+
+	return nil, false
+}
+
+// defend returns the main line after a threat of side a, when every
+// defence loses.
+func (s *searcher) defend(a, depth int) ([]int, bool) {
+	d := 1 - a
+
+	wins := s.winningCells(a)
+	switch {
+	case len(wins) > 1:
+		return []int{wins[0], wins[1]}, true
+	case len(wins) == 1:
+		s.place(wins[0], d)
+		line, ok := s.attack(a, depth-1)
+		s.remove(wins[0], d)
+
+		return append([]int{wins[0]}, line...), ok
+	}
+
+	var main []int
+
+	for _, i := range s.defences(a, d) {
+		s.place(i, d)
+		line, ok := s.attack(a, depth-1)
+		s.remove(i, d)
+
+		if !ok {
+			return nil, false
+		}
+
+		if main == nil {
+			main = append([]int{i}, line...)
+		}
+	}
+
+	return main, main != nil
+}
+
+// threats returns the moves of side a which make a four, and for VCT also
+// those which make a three: a line which can become a double four.
+func (s *searcher) threats(a int) []int {
+	fours := s.cells(a, 2)
+
+	if s.kind != KindVCT {
+		return fours
+	}
+
+	res := slices.Clone(fours)
+
+	for _, i := range s.cells(a, 3) {
+		if slices.Contains(fours, i) {
+			continue
+		}
+
+		s.place(i, a)
+		if s.hasDoubleFour(a, i) {
+			res = append(res, i)
+		}
+		s.remove(i, a)
+	}
+
+	return res
+}
+
+// hasDoubleFour reports whether side a, which has no winning cell, has a
+// move which makes two at once after its stone in cell i. A new double four
+// needs a line through i, so only the cells of those lines are tried, and
+// only the lines through a move can have become wins.
+func (s *searcher) hasDoubleFour(a, i int) bool {
+	for _, w := range s.cellWindows[i] {
+		if !s.open(w, a, len(s.windows[w])-2) {
+			continue
+		}
+
+		for _, j := range s.windows[w] {
+			if s.board[j] != game.PlayerNone {
+				continue
+			}
+
+			s.place(j, a)
+			n := len(s.winsThrough(a, j))
+			s.remove(j, a)
+
+			if n > 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// winsThrough returns the winning cells of side a in the lines through
+// cell i.
+func (s *searcher) winsThrough(a, i int) []int {
+	var res []int
+
+	for _, w := range s.cellWindows[i] {
+		if !s.open(w, a, len(s.windows[w])-1) {
+			continue
+		}
+
+		for _, j := range s.windows[w] {
+			if s.board[j] == game.PlayerNone && !slices.Contains(res, j) {
+				res = append(res, j)
+			}
+		}
+	}
+
+	return res
+}
+
+// defences returns the replies of side d to a threat of side a: the cells
+// of the lines a lacks at most three stones of, and the fours of d which
+// force a to answer.
+func (s *searcher) defences(a, d int) []int {
+	res := s.cells(a, 3)
+
+	for _, i := range s.cells(d, 2) {
+		if !slices.Contains(res, i) {
+			res = append(res, i)
+		}
+	}
+
+	return res
+}
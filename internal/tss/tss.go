@@ -0,0 +1,156 @@
+package tss
+
+import (
+	"errors"
+	"slices"
+	"tictactoe/internal/game"
+)
+
+// MinWinLength is the win length from which threat sequences are searched,
+// shorter lines are won by plain search.
+const MinWinLength = 5
+
+const (
+	// DefaultDepth is the number of threats a sequence may take.
+	DefaultDepth = 7
+	// MaxNodes stops searches which would take too long.
+	MaxNodes = 20000
+)
+
+type Kind string
+
+const (
+	// KindVCF is a victory by continuous fours, every threat makes a line one
+	// stone short of a win, which the defender can only block in one cell.
+	KindVCF Kind = "vcf"
+	// KindVCT is a victory by continuous threats, which may also be threes:
+	// lines which threaten to become a double four.
+	KindVCT Kind = "vct"
+)
+
+// Result is a forced win of the attacker. Moves is the main line, the
+// threats of the attacker and the replies of the defender in turn, ending
+// with the winning move. Depth is the number of moves of the attacker.
+type Result struct {
+	Win   bool   `json:"win"`
+	Kind  Kind   `json:"kind,omitempty"`
+	Moves []int  `json:"moves,omitempty"`
+	Depth int    `json:"depth,omitempty"`
+	Nodes uint64 `json:"nodes"`
+}
+
+// Supported reports whether threat sequences decide the game, which needs
+// long lines under the standard rules where the first line wins.
+func Supported(g *game.Game) bool {
+	rules := g.GetRules()
+
+	return rules.Placement() && rules.LineWinner(g, g.PlayerTurn) == g.PlayerTurn && g.WinLength >= MinWinLength &&
+		g.Scoring == game.ScoringNone && g.StonesPerTurn <= 1
+}
+
+// Search looks for a forced win of the attacker with up to depth threats,
+// as if it was the turn of the attacker. Shorter wins are found first.
+func Search(g *game.Game, attacker game.Player, kind Kind, depth int) (Result, error) {
+	if !Supported(g) {
+		return Result{}, errors.New("threat sequences are only searched for the standard rules with long lines")
+	}
+
+	if attacker != game.PlayerX && attacker != game.PlayerO {
+		return Result{}, errors.New("invalid attacker")
+	}
+
+	if g.IsOver() {
+		return Result{}, errors.New("game is already over")
+	}
+
+	s := newSearcher(g, kind)
+	a := side(attacker)
+
+	for d := 1; d <= depth; d++ {
+		if moves, ok := s.attack(a, d); ok {
+			return Result{Win: true, Kind: kind, Moves: moves, Depth: (len(moves) + 1) / 2, Nodes: s.nodes}, nil
+		}
+
+		if s.nodes >= MaxNodes {
+			break
+		}
+	}
+
+	return Result{Nodes: s.nodes}, nil
+}
+
+// Defend returns the moves of the player to move which leave the opponent
+// without a forced win of the kind. It is nil when the opponent has no such
+// win at all, and empty when nothing defends.
+func Defend(g *game.Game, kind Kind, depth int) ([]int, error) {
+	opponent := g.PlayerTurn.Opponent()
+
+	threat, err := Search(g, opponent, kind, depth)
+	if err != nil || !threat.Win {
+		return nil, err
+	}
+
+	s := newSearcher(g, kind)
+	d, a := side(g.PlayerTurn), side(opponent)
+	res := []int{}
+
+	for _, i := range s.defences(a, d) {
+		s.place(i, d)
+
+		refuted := true
+		for n := 1; n <= depth && refuted; n++ {
+			_, won := s.attack(a, n)
+			refuted = !won && s.nodes < MaxNodes
+		}
+
+		s.remove(i, d)
+
+		if refuted {
+			res = append(res, i)
+		}
+	}
+
+	slices.Sort(res)
+
+	return res, nil
+}
+
+// BestMove returns a move decided by threats: a win at once, a block of
+// one, the start of a forced win or a defence against one of the opponent,
+// fours before threes. It is false when threats decide nothing.
+func BestMove(g *game.Game) (int, bool) {
+	if !Supported(g) || g.IsOver() {
+		return 0, false
+	}
+
+	s := newSearcher(g, KindVCF)
+	p, o := side(g.PlayerTurn), side(g.PlayerTurn.Opponent())
+
+	if wins := s.winningCells(p); len(wins) > 0 {
+		return wins[0], true
+	}
+
+	if wins := s.winningCells(o); len(wins) > 0 {
+		return wins[0], true
+	}
+
+	for _, kind := range []Kind{KindVCF, KindVCT} {
+		if res, err := Search(g, g.PlayerTurn, kind, DefaultDepth); err == nil && res.Win {
+			return res.Moves[0], true
+		}
+
+		if moves, err := Defend(g, kind, DefaultDepth); err == nil && len(moves) > 0 {
+			return moves[0], true
+		}
+	}
+
+	return 0, false
+}
+
+func side(p game.Player) int {
+	if p == game.PlayerO {
+		return 1
+	}
+
+	return 0
+}
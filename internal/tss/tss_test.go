@@ -0,0 +1,119 @@
+package tss
+
+import (
+	"slices"
+	"testing"
+	"tictactoe/internal/game"
+)
+
+func cell(x, y int) int {
+	return x + y*15
+}
+
+// newGame plays the stones of X and O in turn on 15x15 with win length 5.
+func newGame(t *testing.T, x, o []int) *game.Game {
+	g, _ := game.NewGame(15, 5)
+
+	for k := range x {
+		g.MakeMoveByIndex(x[k])
+		if k < len(o) {
+			g.MakeMoveByIndex(o[k])
+		}
+	}
+
+	if g.IsOver() {
+		t.Fatalf("Expected the game to go on")
+	}
+
+	return g
+}
+
+var fillers = []int{cell(0, 0), cell(14, 0), cell(0, 14), cell(14, 14)}
+
+func TestVCF(t *testing.T) {
+	// two closed threes crossing in (6, 7), which makes a double four
+	g := newGame(t,
+		[]int{cell(3, 7), cell(4, 7), cell(5, 7), cell(6, 4), cell(6, 5), cell(6, 6)},
+		append([]int{cell(2, 7), cell(6, 3)}, fillers...),
+	)
+
+	res, err := Search(g, game.PlayerX, KindVCF, DefaultDepth)
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	if !res.Win || res.Depth != 2 || res.Moves[0] != cell(6, 7) {
+		t.Fatalf("Expected a win in 2 starting at (6, 7), got %+v", res)
+	}
+
+	if res, _ := Search(g, game.PlayerO, KindVCF, DefaultDepth); res.Win {
+		t.Fatalf("Expected no win for O, got %+v", res)
+	}
+}
+
+func TestVCT(t *testing.T) {
+	// two open twos crossing in (7, 7), which makes a double three
+	g := newGame(t,
+		[]int{cell(5, 7), cell(6, 7), cell(7, 5), cell(7, 6)},
+		fillers,
+	)
+
+	if res, _ := Search(g, game.PlayerX, KindVCF, DefaultDepth); res.Win {
+		t.Fatalf("Expected no continuous fours, got %+v", res)
+	}
+
+	res, err := Search(g, game.PlayerX, KindVCT, DefaultDepth)
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+
+	if !res.Win || res.Kind != KindVCT {
+		t.Fatalf("Expected a win by threats, got %+v", res)
+	}
+
+}
+
+func TestDefend(t *testing.T) {
+	// the double four of TestVCF with O to move
+	g := newGame(t,
+		[]int{cell(3, 7), cell(4, 7), cell(5, 7), cell(6, 4), cell(6, 5), cell(6, 6), cell(10, 0)},
+		append([]int{cell(2, 7), cell(6, 3)}, fillers...),
+	)
+
+	defences, err := Defend(g, KindVCF, DefaultDepth)
+	if err != nil {
+		t.Fatalf("Failed to defend: %v", err)
+	}
+
+	if !slices.Contains(defences, cell(6, 7)) {
+		t.Fatalf("Expected (6, 7) to defend, got %v", defences)
+	}
+
+	if slices.Contains(defences, cell(14, 7)) {
+		t.Fatalf("Expected a far cell not to defend, got %v", defences)
+	}
+}
+
+func TestBestMove(t *testing.T) {
+	g := newGame(t,
+		[]int{cell(3, 3), cell(4, 3), cell(5, 3), cell(6, 3)},
+		[]int{cell(3, 9), cell(4, 9), cell(5, 9), cell(6, 9)},
+	)
+
+	if i, ok := BestMove(g); !ok || (i != cell(2, 3) && i != cell(7, 3)) {
+		t.Fatalf("Expected X to complete the line, got %d", i)
+	}
+
+	g, _ = game.NewGame(15, 5)
+	if _, ok := BestMove(g); ok {
+		t.Fatalf("Expected threats to decide nothing on an empty board")
+	}
+}
+
+func TestSupported(t *testing.T) {
+	g, _ := game.NewGame(3, 3)
+
+	if _, err := Search(g, game.PlayerX, KindVCF, DefaultDepth); err == nil {
+		t.Fatalf("Expected an error for a short win length")
+	}
+}
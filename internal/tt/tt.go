@@ -0,0 +1,179 @@
+package tt
+
+import (
+	"sync/atomic"
+)
+
+// DefaultMegabytes is the default memory of a table.
+const DefaultMegabytes = 64
+
+// bucketSize is the number of slots a key may be stored in, 64 bytes.
+const bucketSize = 4
+
+// Bound tells how the value of an entry relates to the true value.
+type Bound uint8
+
+const (
+	BoundNone Bound = iota
+	BoundExact
+	// BoundLower is a value the true one is at least, after a cutoff.
+	BoundLower
+	// BoundUpper is a value the true one is at most, when no move raised
+	// alpha.
+	BoundUpper
+)
+
+// Entry is the result of a search of a position. Depth is the depth it was
+// searched to, deeper entries are kept over shallower ones.
+type Entry struct {
+	Depth int
+	Bound Bound
+	Value int
+	Move  int
+}
+
+// slot holds the key xor'ed with the data, so an entry torn by concurrent
+// writers does not match its key and is ignored.
+type slot struct {
+	key  atomic.Uint64
+	data atomic.Uint64
+}
+
+// Table is a fixed-size transposition table which is safe for concurrent
+// use without locks. Positions are kept in buckets of four slots, and a new
+// position replaces the entry of an older search or the shallowest one.
+type Table struct {
+	slots      []slot
+	mask       uint64
+	generation atomic.Uint32
+}
+
+// New returns a table using about the given megabytes of memory.
+func New(megabytes int) *Table {
+	n := uint64(megabytes) << 20 / 16 / bucketSize
+
+	buckets := uint64(1)
+	for buckets*2 <= n {
+		buckets *= 2
+	}
+
+	return &Table{
+		slots: make([]slot, buckets*bucketSize),
+		mask:  buckets - 1,
+	}
+}
+
+// NewSearch ages the entries of earlier searches, which makes them the
+// first to be replaced.
+func (t *Table) NewSearch() {
+	t.generation.Add(1)
+}
+
+// Probe returns the entry of the position with the given key.
+func (t *Table) Probe(key uint64) (Entry, bool) {
+	bucket := t.bucket(key)
+
+	for i := range bucket {
+		s := &bucket[i]
+		data := s.data.Load()
+		if data != 0 && s.key.Load()^data == key {
+			return unpack(data), true
+		}
+	}
+
+	return Entry{}, false
+}
+
+// Store keeps the entry of the position with the given key. An entry of the
+// same position is only replaced by a deeper or exact one, or when it is
+// from an earlier search.
+func (t *Table) Store(key uint64, e Entry) {
+	bucket := t.bucket(key)
+	generation := uint8(t.generation.Load() & 0x3f)
+	replace, worst := 0, int(^uint(0)>>1)
+
+	for i := range bucket {
+		s := &bucket[i]
+		data := s.data.Load()
+
+		if data == 0 {
+			replace, worst = i, -1<<31
+			continue
+		}
+
+		old := unpack(data)
+
+		if s.key.Load()^data == key {
+			if e.Depth < old.Depth && e.Bound != BoundExact && generationOf(data) == generation {
+				return
+			}
+
+			replace = i
+			break
+		}
+
+		age := int((generation - generationOf(data)) & 0x3f)
+		if score := old.Depth - 8*age; score < worst {
+			replace, worst = i, score
+		}
+	}
+
+	data := pack(e, generation)
+	bucket[replace].data.Store(data)
+	bucket[replace].key.Store(key ^ data)
+}
+
+// Clear removes all entries.
+func (t *Table) Clear() {
+	for i := range t.slots {
+		t.slots[i].data.Store(0)
+		t.slots[i].key.Store(0)
+	}
+}
+
+// Usage returns the permille of sampled slots used by the current search.
+func (t *Table) Usage() int {
+	n := min(len(t.slots), 1000)
+	generation := uint8(t.generation.Load() & 0x3f)
+	used := 0
+
+	for i := 0; i < n; i++ {
+		if data := t.slots[i].data.Load(); data != 0 && generationOf(data) == generation {
+			used++
+		}
+	}
+
+	return used * 1000 / n
+}
+
+func (t *Table) bucket(key uint64) []slot {
+	i := (key & t.mask) * bucketSize
+
+	return t.slots[i : i+bucketSize]
+}
+
+// pack puts the value into the low 32 bits, followed by 16 bits of the move,
+// 8 bits of the depth, 2 bits of the bound and 6 bits of the generation.
+// The bound of a used slot is never BoundNone, so its data is never 0.
+func pack(e Entry, generation uint8) uint64 {
+	depth := min(max(e.Depth, 0), 0xff)
+
+	return uint64(uint32(int32(e.Value))) |
+		uint64(uint16(e.Move+1))<<32 |
+		uint64(depth)<<48 |
+		uint64(e.Bound&0x3)<<56 |
+		uint64(generation&0x3f)<<58
+}
+
+func unpack(data uint64) Entry {
+	return Entry{
+		Value: int(int32(uint32(data))),
+		Move:  int(uint16(data>>32)) - 1,
+		Depth: int(uint8(data >> 48)),
+		Bound: Bound(data >> 56 & 0x3),
+	}
+}
+
+func generationOf(data uint64) uint8 {
+	return uint8(data >> 58 & 0x3f)
+}
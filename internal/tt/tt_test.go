@@ -0,0 +1,83 @@
+package tt
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestProbe(t *testing.T) {
+	table := New(1)
+	e := Entry{Depth: 9, Bound: BoundExact, Value: -65530, Move: 4}
+
+	table.Store(42, e)
+
+	if got, ok := table.Probe(42); !ok || got != e {
+		t.Fatalf("Expected %v, got %v", e, got)
+	}
+
+	if _, ok := table.Probe(43); ok {
+		t.Fatalf("Expected no entry for another key")
+	}
+
+	table.Clear()
+
+	if _, ok := table.Probe(42); ok {
+		t.Fatalf("Expected no entry after clearing")
+	}
+}
+
+func TestReplacement(t *testing.T) {
+	table := New(1)
+	table.Store(1, Entry{Depth: 8, Bound: BoundLower, Value: 1, Move: 0})
+	table.Store(1, Entry{Depth: 3, Bound: BoundUpper, Value: 2, Move: 1})
+
+	if e, _ := table.Probe(1); e.Depth != 8 {
+		t.Fatalf("Expected the deeper entry to be kept, got %v", e)
+	}
+
+	table.NewSearch()
+	table.Store(1, Entry{Depth: 3, Bound: BoundUpper, Value: 2, Move: 1})
+
+	if e, _ := table.Probe(1); e.Depth != 3 {
+		t.Fatalf("Expected the entry of an older search to be replaced, got %v", e)
+	}
+
+	// keys of one bucket, the shallowest entry goes first
+	stride := table.mask + 1
+	for i := uint64(0); i < bucketSize; i++ {
+		table.Store(2+i*stride, Entry{Depth: 10 + int(i), Bound: BoundExact})
+	}
+	table.Store(2+bucketSize*stride, Entry{Depth: 20, Bound: BoundExact})
+
+	if _, ok := table.Probe(2); ok {
+		t.Fatalf("Expected the shallowest entry to be replaced")
+	}
+
+	if _, ok := table.Probe(2 + bucketSize*stride); !ok {
+		t.Fatalf("Expected the new entry to be stored")
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	table := New(1)
+	wg := &sync.WaitGroup{}
+
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < 10000; i++ {
+				key := uint64(i % 64)
+				table.Store(key, Entry{Depth: w, Bound: BoundExact, Value: int(key), Move: int(key)})
+
+				if e, ok := table.Probe(key); ok && (e.Value != int(key) || e.Move != int(key)) {
+					t.Errorf("Expected a consistent entry for %d, got %v", key, e)
+					return
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,208 @@
+package variant
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"tictactoe/internal/game"
+)
+
+// Variant is a declarative game definition loaded from a JSON file. It is
+// compiled down to a game.Game: cells outside Width x Height and Blocked
+// cells hold game.PlayerBlocked, and the rest maps onto the game fields.
+type Variant struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Description    string   `json:"description,omitempty"`
+	Width          int      `json:"width"`
+	Height         int      `json:"height"`
+	WinLength      int      `json:"winLength"`
+	Blocked        []string `json:"blocked,omitempty"`
+	StartingPlayer string   `json:"startingPlayer,omitempty"`
+	Misere         bool     `json:"misere,omitempty"`
+	Directions     []string `json:"directions,omitempty"`
+	StonesPerTurn  int      `json:"stonesPerTurn,omitempty"`
+}
+
+// Size is the side of the square board the variant is played on.
+func (v *Variant) Size() int {
+	return max(v.Width, v.Height)
+}
+
+func (v *Variant) Validate() error {
+	if v.ID == "" || strings.ContainsAny(v.ID, " /\\") {
+		return fmt.Errorf("invalid variant id %q", v.ID)
+	}
+
+	if v.Width < 1 || v.Height < 1 {
+		return fmt.Errorf("variant %s: invalid board dimensions %dx%d", v.ID, v.Width, v.Height)
+	}
+
+	if v.WinLength < 1 || v.WinLength > v.Size() {
+		return fmt.Errorf("variant %s: invalid win length %d", v.ID, v.WinLength)
+	}
+
+	switch v.StartingPlayer {
+	case "", string(game.PlayerX), string(game.PlayerO):
+	default:
+		return fmt.Errorf("variant %s: invalid starting player %q", v.ID, v.StartingPlayer)
+	}
+
+	if v.StonesPerTurn < 0 {
+		return fmt.Errorf("variant %s: invalid stones per turn %d", v.ID, v.StonesPerTurn)
+	}
+
+	_, err := v.NewGame()
+
+	return err
+}
+
+// NewGame returns the empty board of the variant.
+func (v *Variant) NewGame() (*game.Game, error) {
+	g, err := game.NewGame(v.Size(), v.WinLength)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.Apply(g); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// blocked returns the cells of the board of the variant which can not be
+// played.
+func (v *Variant) blocked() (map[int]bool, error) {
+	size := v.Size()
+	res := map[int]bool{}
+
+	// The board is anchored at a1, so the unused rows are at the top.
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if x >= v.Width || y < size-v.Height {
+				res[x+y*size] = true
+			}
+		}
+	}
+
+	for _, str := range v.Blocked {
+		x, y, err := game.ParseCoordinates(str, size)
+		if err != nil {
+			return nil, fmt.Errorf("variant %s: invalid blocked cell: %v", v.ID, err)
+		}
+
+		res[x+y*size] = true
+	}
+
+	return res, nil
+}
+
+// Apply sets the rules and the blocked cells of the variant on a game of the
+// same size, e.g. a position given as a game string. The position must not
+// have stones on the blocked cells nor block any other cell.
+func (v *Variant) Apply(g *game.Game) error {
+	if g.Size != v.Size() {
+		return fmt.Errorf("variant %s is played on %dx%d, got %dx%d", v.ID, v.Size(), v.Size(), g.Size, g.Size)
+	}
+
+	blocked, err := v.blocked()
+	if err != nil {
+		return err
+	}
+
+	for i, p := range g.Board {
+		switch {
+		case blocked[i] && p == game.PlayerNone:
+			g.Board[i] = game.PlayerBlocked
+		case blocked[i] != (p == game.PlayerBlocked):
+			return fmt.Errorf("variant %s: cell %s does not match its board", v.ID, g.FormatMove(i))
+		}
+	}
+
+	g.WinLength = v.WinLength
+	g.Misere = v.Misere
+	g.Directions = nil
+
+	for _, name := range v.Directions {
+		d, err := game.ParseDirection(name)
+		if err != nil {
+			return fmt.Errorf("variant %s: %v", v.ID, err)
+		}
+
+		g.Directions = append(g.Directions, d)
+	}
+
+	first := game.Player(game.PlayerX)
+	if v.StartingPlayer == string(game.PlayerO) {
+		first = game.PlayerO
+	}
+
+	g.SetTurnOrder(first, v.StonesPerTurn)
+	g.CheckWin()
+
+	return nil
+}
+
+func Load(path string) (*Variant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read variant: %v", err)
+	}
+
+	v := &Variant{}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("failed to parse variant %s: %v", path, err)
+	}
+
+	if v.ID == "" {
+		v.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	if err := v.Validate(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// LoadDir loads every *.json file of the directory. A missing directory
+// has no variants.
+func LoadDir(dir string) (map[string]*Variant, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]*Variant{}
+
+	for _, path := range paths {
+		v, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := res[v.ID]; ok {
+			return nil, fmt.Errorf("duplicate variant id %q in %s", v.ID, path)
+		}
+
+		res[v.ID] = v
+	}
+
+	return res, nil
+}
+
+// Sorted returns the variants ordered by ID.
+func Sorted(variants map[string]*Variant) []*Variant {
+	res := make([]*Variant, 0, len(variants))
+	for _, v := range variants {
+		res = append(res, v)
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+
+	return res
+}
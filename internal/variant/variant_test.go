@@ -0,0 +1,63 @@
+package variant
+
+import (
+	"testing"
+	"tictactoe/internal/game"
+)
+
+func TestLoadDir(t *testing.T) {
+	variants, err := LoadDir("../../variants")
+	if err != nil {
+		t.Fatalf("Failed to load variants: %v", err)
+	}
+
+	for _, id := range []string{"misere", "cornerless", "wide", "orthogonal"} {
+		if variants[id] == nil {
+			t.Fatalf("Expected variant %s to be loaded", id)
+		}
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	v := &Variant{ID: "wide", Width: 4, Height: 3, WinLength: 3, StartingPlayer: "O", Blocked: []string{"b2"}}
+
+	g, err := v.NewGame()
+	if err != nil {
+		t.Fatalf("Failed to create the variant game: %v", err)
+	}
+
+	if string(g.Board) != "####_____#______" {
+		t.Fatalf("Unexpected board %s", string(g.Board))
+	}
+
+	if g.PlayerTurn != game.PlayerO {
+		t.Fatalf("Expected PlayerO to start, got %c", g.PlayerTurn)
+	}
+
+	if err := (&Variant{ID: "bad", Width: 3, Height: 3, WinLength: 4}).Validate(); err == nil {
+		t.Fatalf("Expected an error for a win length longer than the board")
+	}
+}
+
+func TestApply(t *testing.T) {
+	v := &Variant{ID: "cornerless", Width: 3, Height: 3, WinLength: 3, Blocked: []string{"a1", "c3"}}
+
+	g, _ := game.FromString("_ _X___O___")
+	if err := v.Apply(g); err != nil {
+		t.Fatalf("Failed to apply the variant: %v", err)
+	}
+
+	if string(g.Board) != "_X#__O#__" {
+		t.Fatalf("Expected the corners to be blocked, got %s", string(g.Board))
+	}
+
+	g, _ = game.FromString("_ ______X__")
+	if err := v.Apply(g); err == nil {
+		t.Fatalf("Expected an error for a stone on a blocked cell")
+	}
+
+	g, _ = game.FromString("_ ____#____")
+	if err := v.Apply(g); err == nil {
+		t.Fatalf("Expected an error for a cell blocked outside the variant")
+	}
+}
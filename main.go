@@ -1,7 +1,14 @@
 package main
 
-import "tictactoe/internal/server"
+import (
+	"flag"
+	"tictactoe/internal/server"
+	"tictactoe/internal/tt"
+)
 
 func main() {
-	server.NewServer().Start(4000)
+	hash := flag.Int("hash", tt.DefaultMegabytes, "transposition table size in megabytes")
+	flag.Parse()
+
+	server.NewServer(*hash).Start(4000)
 }
@@ -4,6 +4,7 @@ export class TicTacToeBoard extends HTMLElement {
   wonPosition = null
   player = 'X'
   playerTurn = 'X'
+  winLength = null
 
   constructor () {
     super()
@@ -82,10 +83,15 @@ export class TicTacToeBoard extends HTMLElement {
   }
 
   setSize (size) {
+    const offset = Math.floor((size - this.size) / 2)
+    this.resize(size, offset, offset)
+  }
+
+  // Moves the cells onto a board of the given size where the old board starts at the offsets,
+  // like the server does when an expanding board grows.
+  resize (size, xOffSet, yOffSet) {
     const newBoard = new Array(size * size).fill(null)
 
-    const xOffSet = Math.floor((size - this.size) / 2)
-    const yOffSet = Math.floor((size - this.size) / 2)
     for (let x = 0; x < this.size; x++) {
       for (let y = 0; y < this.size; y++) {
         newBoard[(x + xOffSet) + (y + yOffSet) * size] = this.board[x + y * this.size] || null
@@ -159,7 +165,7 @@ export class TicTacToeBoard extends HTMLElement {
   }
 
   checkWin () {
-    const winLength = this.size <= 4 ? this.size : this.size - 1
+    const winLength = this.winLength || (this.size <= 4 ? this.size : this.size - 1)
     const lines = this.getWinPositions(this.size, winLength)
 
     for (const line of lines) {
@@ -178,12 +184,12 @@ export class TicTacToeBoard extends HTMLElement {
     const res = []
 
     // Vertical
-    for (let xOffset = 0; xOffset <= size - winLength; xOffset++) {
-      for (let x = 0; x < winLength; x++) {
+    for (let yOffset = 0; yOffset <= size - winLength; yOffset++) {
+      for (let x = 0; x < size; x++) {
         const column = []
 
         for (let y = 0; y < winLength; y++) {
-          column.push((y * size) + (x + xOffset))
+          column.push((y + yOffset) * size + x)
         }
 
         res.push(column)
@@ -191,12 +197,12 @@ export class TicTacToeBoard extends HTMLElement {
     }
 
     // Horizontal
-    for (let yOffset = 0; yOffset <= size - winLength; yOffset++) {
-      for (let y = 0; y < winLength; y++) {
+    for (let xOffset = 0; xOffset <= size - winLength; xOffset++) {
+      for (let y = 0; y < size; y++) {
         const row = []
 
         for (let x = 0; x < winLength; x++) {
-          row.push((y + yOffset) * size + x)
+          row.push(y * size + x + xOffset)
         }
 
         res.push(row)
@@ -11,6 +11,8 @@ const statusEnum = {
 export class TicTacToe extends HTMLElement {
   #status = statusEnum.WAITING_FOR_PLAYER_TURN
   #mapBuildProgress = 0
+  originX = 0
+  originY = 0
 
   constructor () {
     super()
@@ -104,11 +106,29 @@ export class TicTacToe extends HTMLElement {
     }
   }
 
+  // The margin of an expanding board, set with the "expand" attribute. Its win length stays the one of
+  // the "win" attribute while the board grows.
+  get expand () {
+    return Number(this.getAttribute('expand')) || 0
+  }
+
+  connectedCallback () {
+    if (this.expand) {
+      this.board.winLength = Number(this.getAttribute('win')) || 3
+    }
+  }
+
   getGameString () {
-    return [
+    const tokens = [
       this.board.wonPosition?.[0] || '_',
       this.board.board.map(i => i || '_').join(''),
-    ].join(' ')
+    ]
+
+    if (this.expand) {
+      tokens.push(`win=${this.board.winLength}`, `expand=${this.expand}`, `ox=${this.originX}`, `oy=${this.originY}`)
+    }
+
+    return tokens.join(' ')
   }
 
   async waitForMapBuild () {
@@ -137,6 +157,11 @@ export class TicTacToe extends HTMLElement {
   async nextMove () {
     this.setStatus(statusEnum.WAITING_FOR_NEXT_TURN_FROM_SERVER)
 
+    // Boards from 7x7 on have no maps either, the server searches their moves.
+    if (this.expand || this.board.size >= 7) {
+      return this.nextExpandingMove()
+    }
+
     const statusRes = await fetch(`/api/maps/status?game=${this.getGameString()}`, { method: 'GET' })
       .then((res) => res.json())
       .catch((err) => this.onError(err))
@@ -173,6 +198,35 @@ export class TicTacToe extends HTMLElement {
     }
   }
 
+  // Expanding boards have no maps, the server grows the board and tells how in "resize".
+  async nextExpandingMove () {
+    const moveRes = await fetch(`/api/next-move?game=${encodeURIComponent(this.getGameString())}`, { method: 'GET' })
+      .then((res) => res.json())
+      .catch((err) => this.onError(err))
+
+    if (this.#status !== statusEnum.WAITING_FOR_NEXT_TURN_FROM_SERVER) {
+      return
+    }
+
+    const { x, y, resize } = moveRes.data
+    if (resize) {
+      this.board.resize(resize.size, resize.offsetX, resize.offsetY)
+      this.originX -= resize.offsetX
+      this.originY -= resize.offsetY
+    }
+
+    const i = x + y * this.board.size
+    if (this.board.board[i]) {
+      console.error('Invalid move received from the server.')
+      return this.setStatus(statusEnum.CRASHED)
+    }
+    this.board.setValue(i, 'O')
+
+    if (this.board.playerTurn === 'X' && !this.board.wonPosition) {
+      return this.setStatus(statusEnum.WAITING_FOR_PLAYER_TURN)
+    }
+  }
+
   async updateChances () {
     this.shadowRoot.querySelector('.chances').textContent = 'Loading...'
 